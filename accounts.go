@@ -0,0 +1,297 @@
+package opslevel
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AccountTokenConfig is where an account's API token comes from - exactly one source must be set
+type AccountTokenConfig struct {
+	Value    string        `yaml:"value"`
+	Env      string        `yaml:"env"`
+	File     string        `yaml:"file"`
+	Command  []string      `yaml:"command"`
+	CacheTTL time.Duration `yaml:"cacheTTL"` // How long a command's token is reused - until it is rejected when 0
+}
+
+// Provider returns the TokenProvider for the configured source
+func (c AccountTokenConfig) Provider() (TokenProvider, error) {
+	var providers []TokenProvider
+	if c.Value != "" {
+		providers = append(providers, StaticTokenProvider(c.Value))
+	}
+	if c.Env != "" {
+		providers = append(providers, EnvTokenProvider(c.Env))
+	}
+	if c.File != "" {
+		providers = append(providers, NewFileTokenProvider(c.File))
+	}
+	if len(c.Command) > 0 {
+		providers = append(providers, NewCachedTokenProvider(NewCommandTokenProvider(c.Command[0], c.Command[1:]...), c.CacheTTL))
+	}
+	switch len(providers) {
+	case 0:
+		return nil, errors.New("no token source, set one of value, env, file or command")
+	case 1:
+		return providers[0], nil
+	default:
+		return nil, errors.New("more than one token source, set only one of value, env, file or command")
+	}
+}
+
+// AccountConfig configures the client of an OpsLevel account
+type AccountConfig struct {
+	URL        string             `yaml:"url"`
+	Token      AccountTokenConfig `yaml:"token"`
+	Headers    map[string]string  `yaml:"headers"`
+	Visibility string             `yaml:"visibility"`
+	PageSize   int                `yaml:"pageSize"`
+}
+
+// Options returns the client options for the account
+func (c AccountConfig) Options() ([]Option, error) {
+	provider, err := c.Token.Provider()
+	if err != nil {
+		return nil, err
+	}
+	options := []Option{SetTokenProvider(provider)}
+	if c.URL != "" {
+		options = append(options, SetURL(c.URL))
+	}
+	if len(c.Headers) > 0 {
+		options = append(options, SetHeaders(c.Headers))
+	}
+	if c.Visibility != "" {
+		options = append(options, SetAPIVisibility(c.Visibility))
+	}
+	if c.PageSize > 0 {
+		options = append(options, SetPageSize(c.PageSize))
+	}
+	return options, nil
+}
+
+// AccountsConfig is a file of named accounts, e.g.
+//
+//	default: prod
+//	accounts:
+//	  prod:
+//	    token:
+//	      env: OPSLEVEL_PROD_TOKEN
+//	  sandbox:
+//	    url: https://sandbox.opslevel.example
+//	    pageSize: 50
+//	    token:
+//	      command: ["vault", "read", "-field=token", "secret/opslevel/sandbox"]
+//	      cacheTTL: 15m
+type AccountsConfig struct {
+	Default  string                   `yaml:"default"`
+	Accounts map[string]AccountConfig `yaml:"accounts"`
+}
+
+func ParseAccountsConfig(data []byte) (*AccountsConfig, error) {
+	var config AccountsConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	if len(config.Accounts) == 0 {
+		return nil, errors.New("no accounts configured")
+	}
+	if _, ok := config.Accounts[config.Default]; config.Default != "" && !ok {
+		return nil, fmt.Errorf("default account '%s' is not configured", config.Default)
+	}
+	return &config, nil
+}
+
+func LoadAccountsConfig(path string) (*AccountsConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAccountsConfig(data)
+}
+
+// Account is a named client with its own cache, which the client uses in place of the global Cache
+type Account struct {
+	Name   string
+	Client *Client
+	Cache  *Cacher
+}
+
+// AccountRegistry holds the clients of several OpsLevel accounts by name
+type AccountRegistry struct {
+	mutex    sync.Mutex
+	accounts map[string]*Account
+	Default  string
+}
+
+func NewAccountRegistry() *AccountRegistry {
+	return &AccountRegistry{accounts: map[string]*Account{}}
+}
+
+// NewAccountRegistryFromConfig creates a client for each account - 'options' are applied to every client
+// before the account's own settings
+func NewAccountRegistryFromConfig(config *AccountsConfig, options ...Option) (*AccountRegistry, error) {
+	registry := NewAccountRegistry()
+	registry.Default = config.Default
+	for name, account := range config.Accounts {
+		accountOptions, err := account.Options()
+		if err != nil {
+			return nil, fmt.Errorf("account '%s': %w", name, err)
+		}
+		registry.Register(name, NewGQLClient(append(append([]Option{}, options...), accountOptions...)...))
+	}
+	return registry, nil
+}
+
+// Register adds or replaces the account 'name' with an empty cache that becomes the client's Cacher
+func (r *AccountRegistry) Register(name string, client *Client) *Account {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	account := &Account{Name: name, Client: client, Cache: NewCacher()}
+	client.cache = account.Cache
+	r.accounts[name] = account
+	return account
+}
+
+// Get returns the account 'name' or, when 'name' is empty, the default account
+func (r *AccountRegistry) Get(name string) (*Account, error) {
+	if name == "" {
+		name = r.Default
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if account, ok := r.accounts[name]; ok {
+		return account, nil
+	}
+	return nil, fmt.Errorf("account '%s' is not registered", name)
+}
+
+// Names returns the names of the registered accounts, sorted
+func (r *AccountRegistry) Names() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	output := make([]string, 0, len(r.accounts))
+	for name := range r.accounts {
+		output = append(output, name)
+	}
+	sort.Strings(output)
+	return output
+}
+
+// AccountResult is the outcome of an operation run against one account
+type AccountResult[T any] struct {
+	Account string
+	Value   T
+	Err     error
+}
+
+// Across runs 'fn' against the named accounts, all of them when no names are given, concurrently.
+// The results are in the order of the names.
+func Across[T any](registry *AccountRegistry, fn func(account *Account) (T, error), names ...string) []AccountResult[T] {
+	if len(names) == 0 {
+		names = registry.Names()
+	}
+	output := make([]AccountResult[T], len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		output[i].Account = name
+		account, err := registry.Get(name)
+		if err != nil {
+			output[i].Err = err
+			continue
+		}
+		wg.Add(1)
+		go func(result *AccountResult[T]) {
+			defer wg.Done()
+			result.Value, result.Err = fn(account)
+		}(&output[i])
+	}
+	wg.Wait()
+	return output
+}
+
+// AccountComparison records which accounts each item of a list was found in
+type AccountComparison struct {
+	Accounts []string            // Accounts whose operation succeeded
+	Present  map[string][]string // Accounts each item key was found in
+	Errors   map[string]error    // Accounts whose operation failed
+}
+
+// InAll returns the keys of the items found in every compared account, sorted
+func (c *AccountComparison) InAll() []string {
+	var output []string
+	for key, accounts := range c.Present {
+		if len(accounts) == len(c.Accounts) {
+			output = append(output, key)
+		}
+	}
+	sort.Strings(output)
+	return output
+}
+
+// Missing returns the accounts each item key is missing from, for the items not found in every account
+func (c *AccountComparison) Missing() map[string][]string {
+	output := map[string][]string{}
+	for key, accounts := range c.Present {
+		for _, account := range c.Accounts {
+			if !slices.Contains(accounts, account) {
+				output[key] = append(output[key], account)
+			}
+		}
+	}
+	return output
+}
+
+// String lists each item missing from an account, e.g. "'checkout' is missing from: sandbox"
+func (c *AccountComparison) String() string {
+	missing := c.Missing()
+	keys := make([]string, 0, len(missing))
+	for key := range missing {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var lines []string
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("'%s' is missing from: %s", key, strings.Join(missing[key], ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CompareAcross keys the items each account returned to find the ones that are not in every account
+//
+//	results := Across(registry, func(account *Account) ([]Service, error) {
+//		services, err := account.Client.ListServices(nil)
+//		return services.Nodes, err
+//	})
+//	comparison := CompareAcross(results, func(service Service) string { return service.Name })
+func CompareAcross[T any](results []AccountResult[[]T], key func(T) string) *AccountComparison {
+	comparison := &AccountComparison{
+		Present: map[string][]string{},
+		Errors:  map[string]error{},
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			comparison.Errors[result.Account] = result.Err
+			continue
+		}
+		comparison.Accounts = append(comparison.Accounts, result.Account)
+		seen := map[string]bool{}
+		for _, item := range result.Value {
+			itemKey := key(item)
+			if seen[itemKey] {
+				continue
+			}
+			seen[itemKey] = true
+			comparison.Present[itemKey] = append(comparison.Present[itemKey], result.Account)
+		}
+	}
+	return comparison
+}
@@ -0,0 +1,125 @@
+package opslevel_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+const accountsConfig = `
+default: prod
+accounts:
+  prod:
+    token:
+      env: OPSLEVEL_TEST_PROD_TOKEN
+  sandbox:
+    url: https://sandbox.opslevel.example/
+    visibility: internal
+    pageSize: 50
+    headers:
+      X-Team: platform
+    token:
+      command: ["echo", "sandbox-token"]
+      cacheTTL: 15m
+`
+
+func TestLoadAccountsConfig(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "accounts.yaml")
+	autopilot.Ok(t, os.WriteFile(path, []byte(accountsConfig), 0o600))
+	// Act
+	config, err := ol.LoadAccountsConfig(path)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "prod", config.Default)
+	autopilot.Equals(t, "OPSLEVEL_TEST_PROD_TOKEN", config.Accounts["prod"].Token.Env)
+	autopilot.Equals(t, 50, config.Accounts["sandbox"].PageSize)
+	autopilot.Equals(t, 15*time.Minute, config.Accounts["sandbox"].Token.CacheTTL)
+	autopilot.Equals(t, map[string]string{"X-Team": "platform"}, config.Accounts["sandbox"].Headers)
+}
+
+func TestParseAccountsConfigErrors(t *testing.T) {
+	// Arrange
+	_, noAccounts := ol.ParseAccountsConfig([]byte(`default: prod`))
+	_, badDefault := ol.ParseAccountsConfig([]byte("default: prod\naccounts:\n  sandbox:\n    token:\n      value: x\n"))
+	config, err := ol.ParseAccountsConfig([]byte("accounts:\n  prod:\n    token:\n      value: x\n      env: Y\n"))
+	autopilot.Ok(t, err)
+	// Act
+	_, tokenErr := ol.NewAccountRegistryFromConfig(config)
+	// Assert
+	autopilot.Equals(t, "no accounts configured", noAccounts.Error())
+	autopilot.Equals(t, "default account 'prod' is not configured", badDefault.Error())
+	autopilot.Equals(t, "account 'prod': more than one token source, set only one of value, env, file or command", tokenErr.Error())
+}
+
+func TestAccountRegistryFromConfig(t *testing.T) {
+	// Arrange
+	config, err := ol.ParseAccountsConfig([]byte(accountsConfig))
+	autopilot.Ok(t, err)
+	// Act
+	registry, err := ol.NewAccountRegistryFromConfig(config, ol.SetMaxRetries(0))
+	autopilot.Ok(t, err)
+	prod, err := registry.Get("")
+	autopilot.Ok(t, err)
+	sandbox, err := registry.Get("sandbox")
+	autopilot.Ok(t, err)
+	_, missingErr := registry.Get("staging")
+	// Assert
+	autopilot.Equals(t, []string{"prod", "sandbox"}, registry.Names())
+	autopilot.Equals(t, "prod", prod.Name)
+	autopilot.Assert(t, prod.Cache != sandbox.Cache && prod.Cache != ol.Cache, "expected each account to have its own cache")
+	autopilot.Assert(t, prod.Client.Cacher() == prod.Cache && sandbox.Client.Cacher() == sandbox.Cache, "expected each client to use its account's cache")
+	autopilot.Equals(t, "account 'staging' is not registered", missingErr.Error())
+}
+
+func TestCompareAcrossAccounts(t *testing.T) {
+	// Arrange
+	registry := ol.NewAccountRegistry()
+	registry.Register("prod", ol.NewGQLClient(ol.SetAPIToken("x")))
+	registry.Register("sandbox", ol.NewGQLClient(ol.SetAPIToken("x")))
+	registry.Register("staging", ol.NewGQLClient(ol.SetAPIToken("x")))
+	aliases := map[string][]string{
+		"prod":    {"checkout", "payments", "search"},
+		"sandbox": {"checkout", "search", "search"},
+	}
+	// Act
+	results := ol.Across(registry, func(account *ol.Account) ([]string, error) {
+		if items, ok := aliases[account.Name]; ok {
+			return items, nil
+		}
+		return nil, errors.New("unauthorized")
+	})
+	comparison := ol.CompareAcross(results, func(alias string) string { return alias })
+	// Assert
+	autopilot.Equals(t, 3, len(results))
+	autopilot.Equals(t, "sandbox", results[1].Account)
+	autopilot.Equals(t, []string{"prod", "sandbox"}, comparison.Accounts)
+	autopilot.Equals(t, []string{"checkout", "search"}, comparison.InAll())
+	autopilot.Equals(t, map[string][]string{"payments": {"sandbox"}}, comparison.Missing())
+	autopilot.Equals(t, "'payments' is missing from: sandbox", comparison.String())
+	autopilot.Equals(t, "unauthorized", comparison.Errors["staging"].Error())
+}
+
+func TestAcrossAccountsQueriesEachClient(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query ServiceGet($service:String!){account{service(alias: $service){id,aliases}}}"`,
+		`{"service": "checkout"}`,
+		`{"data": { "account": { "service": { {{ template "id1" }}, "aliases": ["checkout"] }}}}`,
+	)
+	registry := ol.NewAccountRegistry()
+	registry.Register("prod", BestTestClient(t, "accounts/prod", testRequest))
+	// Act
+	results := ol.Across(registry, func(account *ol.Account) (*ol.ServiceId, error) {
+		return account.Client.GetServiceIdWithAlias("checkout")
+	}, "prod", "unknown")
+	// Assert
+	autopilot.Ok(t, results[0].Err)
+	autopilot.Equals(t, id1, results[0].Value.Id)
+	autopilot.Equals(t, "account 'unknown' is not registered", results[1].Err.Error())
+}
@@ -234,6 +234,11 @@ func (client *Client) CreateTriggerDefinition(input CustomActionsTriggerDefiniti
 	if input.EntityType == "" {
 		input.EntityType = CustomActionsEntityTypeEnumService
 	}
+	if input.ManualInputsDefinition != "" {
+		if _, err := ParseManualInputsDefinition(input.ManualInputsDefinition); err != nil {
+			return nil, err
+		}
+	}
 	v := PayloadVariables{
 		"input": input,
 	}
@@ -289,6 +294,11 @@ func (client *Client) UpdateTriggerDefinition(input CustomActionsTriggerDefiniti
 			Errors            []OpsLevelErrors
 		} `graphql:"customActionsTriggerDefinitionUpdate(input: $input)"`
 	}
+	if input.ManualInputsDefinition != nil && *input.ManualInputsDefinition != "" {
+		if _, err := ParseManualInputsDefinition(*input.ManualInputsDefinition); err != nil {
+			return nil, err
+		}
+	}
 	v := PayloadVariables{
 		"input": input,
 	}
@@ -0,0 +1,112 @@
+package opslevel
+
+import (
+	"fmt"
+)
+
+type CustomActionsTriggerEvent struct {
+	Id                ID                                  `graphql:"id"`
+	Status            CustomActionsTriggerEventStatusEnum `graphql:"status"`
+	Timestamps        Timestamps                          `graphql:"timestamps"`
+	TriggerDefinition CustomActionsId                     `graphql:"triggerDefinition"`
+	User              UserId                              `graphql:"user"`
+	ManualInputs      JSON                                `graphql:"manualInputs" scalar:"true"`
+	ResponseBody      string                              `graphql:"responseBody"`
+}
+
+type CustomActionsTriggerEventsConnection struct {
+	Nodes      []CustomActionsTriggerEvent
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type CustomActionsTriggerInvokeInput struct {
+	TriggerDefinition IdentifierInput `json:"triggerDefinition"`
+	EntityId          *ID             `json:"entityId,omitempty"` // Not needed for triggers with the GLOBAL entity type
+	ManualInputs      *JSON           `json:"manualInputs,omitempty"`
+}
+
+func (client *Client) InvokeTriggerDefinition(input CustomActionsTriggerInvokeInput) (*CustomActionsTriggerEvent, error) {
+	var m struct {
+		Payload struct {
+			TriggerEvent CustomActionsTriggerEvent
+			Errors       []OpsLevelErrors
+		} `graphql:"customActionsTriggerInvoke(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("TriggerDefinitionInvoke"))
+	return &m.Payload.TriggerEvent, HandleErrors(err, m.Payload.Errors)
+}
+
+// Invoke validates 'manualInputs' against the trigger's ManualInputsDefinition, applying defaults, before invoking it for 'entity'
+func (c *CustomActionsTriggerDefinition) Invoke(client *Client, entity *ID, manualInputs map[string]any) (*CustomActionsTriggerEvent, error) {
+	if c.Id == "" {
+		return nil, fmt.Errorf("Unable to invoke, invalid CustomActionsTriggerDefinition id: '%s'", c.Id)
+	}
+	if c.EntityType != CustomActionsEntityTypeEnumGlobal && entity == nil {
+		return nil, fmt.Errorf("Unable to invoke, CustomActionsTriggerDefinition '%s' requires an entity", c.Id)
+	}
+	definition, err := c.GetManualInputs()
+	if err != nil {
+		return nil, err
+	}
+	values, err := definition.ValidateInputs(manualInputs)
+	if err != nil {
+		return nil, err
+	}
+	input := CustomActionsTriggerInvokeInput{
+		TriggerDefinition: *NewIdentifier(string(c.Id)),
+		EntityId:          entity,
+	}
+	if len(values) > 0 {
+		inputs := JSON(values)
+		input.ManualInputs = &inputs
+	}
+	return client.InvokeTriggerDefinition(input)
+}
+
+func (c *CustomActionsTriggerDefinition) ListEvents(client *Client, variables *PayloadVariables) (*CustomActionsTriggerEventsConnection, error) {
+	var q struct {
+		Account struct {
+			CustomActionsTriggerDefinition struct {
+				TriggerEvents CustomActionsTriggerEventsConnection `graphql:"triggerEvents(after: $after, first: $first)"`
+			} `graphql:"customActionsTriggerDefinition(input: $input)"`
+		}
+	}
+	if c.Id == "" {
+		return nil, fmt.Errorf("Unable to get trigger events, invalid CustomActionsTriggerDefinition id: '%s'", c.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["input"] = *NewIdentifier(string(c.Id))
+
+	if err := client.Query(&q, *variables, WithName("TriggerEventsList")); err != nil {
+		return nil, err
+	}
+
+	for q.Account.CustomActionsTriggerDefinition.TriggerEvents.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.CustomActionsTriggerDefinition.TriggerEvents.PageInfo.End
+		resp, err := c.ListEvents(client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.CustomActionsTriggerDefinition.TriggerEvents.Nodes = append(q.Account.CustomActionsTriggerDefinition.TriggerEvents.Nodes, resp.Nodes...)
+		q.Account.CustomActionsTriggerDefinition.TriggerEvents.PageInfo = resp.PageInfo
+		q.Account.CustomActionsTriggerDefinition.TriggerEvents.TotalCount += resp.TotalCount
+	}
+	return &q.Account.CustomActionsTriggerDefinition.TriggerEvents, nil
+}
+
+// WithStatus returns the events in the connection that have 'status'
+func (c *CustomActionsTriggerEventsConnection) WithStatus(status CustomActionsTriggerEventStatusEnum) []CustomActionsTriggerEvent {
+	var output []CustomActionsTriggerEvent
+	for _, event := range c.Nodes {
+		if event.Status == status {
+			output = append(output, event)
+		}
+	}
+	return output
+}
@@ -0,0 +1,65 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestInvokeTriggerDefinition(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation TriggerDefinitionInvoke($input:CustomActionsTriggerInvokeInput!){customActionsTriggerInvoke(input: $input){triggerEvent{id,status,timestamps{createdAt,updatedAt},triggerDefinition{aliases,id},user{id,email},manualInputs,responseBody},errors{message,path}}}"`,
+		`{"input": {"triggerDefinition": {"id": "{{ template "id1_string" }}"}, "entityId": "{{ template "id2_string" }}", "manualInputs": "{\"environment\":\"staging\",\"notify\":true,\"title\":\"Rollback\"}"}}`,
+		`{"data": {"customActionsTriggerInvoke": {"triggerEvent": { {{ template "id3" }}, "status": "PENDING", "triggerDefinition": { {{ template "id1" }} }, "user": {"id": "{{ template "id4_string" }}", "email": "kyle@opslevel.com"}, "manualInputs": {"title": "Rollback"}, "responseBody": ""}, "errors": []}}}`,
+	)
+	client := BestTestClient(t, "custom_actions/invoke_trigger", testRequest)
+	trigger := ol.CustomActionsTriggerDefinition{Id: id1, EntityType: ol.CustomActionsEntityTypeEnumService, ManualInputsDefinition: manualInputsDefinition}
+	// Act
+	result, err := trigger.Invoke(client, &id2, map[string]any{"title": "Rollback", "notify": true})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id3, result.Id)
+	autopilot.Equals(t, ol.CustomActionsTriggerEventStatusEnumPending, result.Status)
+	autopilot.Equals(t, "kyle@opslevel.com", result.User.Email)
+}
+
+func TestInvokeTriggerDefinitionInvalidInputs(t *testing.T) {
+	// Arrange
+	trigger := ol.CustomActionsTriggerDefinition{Id: id1, EntityType: ol.CustomActionsEntityTypeEnumGlobal, ManualInputsDefinition: manualInputsDefinition}
+	// Act
+	_, err := trigger.Invoke(nil, nil, map[string]any{})
+	// Assert
+	autopilot.Equals(t, "title: is required", err.Error())
+}
+
+func TestListTriggerEvents(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"query TriggerEventsList($after:String!$first:Int!$input:IdentifierInput!){account{customActionsTriggerDefinition(input: $input){triggerEvents(after: $after, first: $first){nodes{id,status,timestamps{createdAt,updatedAt},triggerDefinition{aliases,id},user{id,email},manualInputs,responseBody},{{ template "pagination_request" }},totalCount}}}}"`,
+		`{ {{ template "first_page_variables" }}, "input": {"id": "{{ template "id1_string" }}"} }`,
+		`{"data": {"account": {"customActionsTriggerDefinition": {"triggerEvents": {"nodes": [
+			{ {{ template "id2" }}, "status": "SUCCESS", "timestamps": {"createdAt": "2023-11-01T12:00:00Z", "updatedAt": "2023-11-01T12:00:05Z"}, "user": {"email": "kyle@opslevel.com"}, "responseBody": "{\"message\": \"ok\"}"}
+		], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1}}}}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"query TriggerEventsList($after:String!$first:Int!$input:IdentifierInput!){account{customActionsTriggerDefinition(input: $input){triggerEvents(after: $after, first: $first){nodes{id,status,timestamps{createdAt,updatedAt},triggerDefinition{aliases,id},user{id,email},manualInputs,responseBody},{{ template "pagination_request" }},totalCount}}}}"`,
+		`{ {{ template "second_page_variables" }}, "input": {"id": "{{ template "id1_string" }}"} }`,
+		`{"data": {"account": {"customActionsTriggerDefinition": {"triggerEvents": {"nodes": [
+			{ {{ template "id3" }}, "status": "FAILURE", "user": {"email": "edgar@opslevel.com"}, "responseBody": "{\"message\": \"failed\"}"}
+		], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1}}}}}`,
+	)
+	requests := []TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "custom_actions/list_trigger_events", requests...)
+	trigger := ol.CustomActionsTriggerDefinition{Id: id1}
+	// Act
+	result, err := trigger.ListEvents(client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, result.TotalCount)
+	autopilot.Equals(t, "{\"message\": \"ok\"}", result.Nodes[0].ResponseBody)
+	autopilot.Equals(t, 2023, result.Nodes[0].Timestamps.CreatedAt.Year())
+	autopilot.Equals(t, id3, result.WithStatus(ol.CustomActionsTriggerEventStatusEnumFailure)[0].Id)
+}
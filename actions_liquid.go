@@ -0,0 +1,268 @@
+package opslevel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/osteele/liquid"
+	"github.com/osteele/liquid/render"
+)
+
+var (
+	liquidVariablePath = regexp.MustCompile(`^\s*([A-Za-z_][\w-]*(?:\.[A-Za-z_][\w-]*|\[\d+\])*)`)
+	liquidPathSegment  = regexp.MustCompile(`[A-Za-z_][\w-]*|\[\d+\]`)
+	liquidAssignment   = regexp.MustCompile(`^\s*([A-Za-z_][\w-]*)`)
+	liquidLoopVariable = regexp.MustCompile(`^\s*([A-Za-z_][\w-]*)\s+in\s`)
+	liquidKeywords     = map[string]bool{"true": true, "false": true, "nil": true, "null": true, "empty": true, "blank": true}
+	// Properties liquid resolves on any array, string or map
+	liquidBuiltinProperties = map[string]bool{"size": true, "first": true, "last": true}
+)
+
+// LiquidPreviewContext is the sample data custom action Liquid templates are rendered against locally
+type LiquidPreviewContext struct {
+	Service      *Service
+	User         *User
+	ManualInputs map[string]any
+	Secrets      []string       // Secret aliases - rendered as placeholders so real values never leave OpsLevel
+	Extra        map[string]any // Additional top level variables, e.g. 'response' for a ResponseTemplate
+}
+
+type LiquidTemplateIssue struct {
+	Line    int
+	Message string
+}
+
+func (i LiquidTemplateIssue) String() string {
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+type LiquidPreview struct {
+	Output             string
+	SyntaxErrors       []LiquidTemplateIssue
+	UndefinedVariables []LiquidTemplateIssue
+}
+
+// Ok returns true when the template has no syntax errors and no undefined variables
+func (p *LiquidPreview) Ok() bool {
+	return len(p.SyntaxErrors) == 0 && len(p.UndefinedVariables) == 0
+}
+
+// Err joins all the issues found while rendering the template
+func (p *LiquidPreview) Err() error {
+	if p.Ok() {
+		return nil
+	}
+	var messages []string
+	for _, issue := range p.SyntaxErrors {
+		messages = append(messages, issue.String())
+	}
+	for _, issue := range p.UndefinedVariables {
+		messages = append(messages, issue.String())
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+// SecretPlaceholder is the value a secret alias renders to in a preview
+func SecretPlaceholder(alias string) string {
+	return fmt.Sprintf("<secret:%s>", alias)
+}
+
+func (c LiquidPreviewContext) Bindings() map[string]any {
+	output := map[string]any{}
+	if c.Service != nil {
+		alias := ""
+		if len(c.Service.Aliases) > 0 {
+			alias = c.Service.Aliases[0]
+		}
+		output["service"] = map[string]any{
+			"id":          string(c.Service.Id),
+			"alias":       alias,
+			"aliases":     c.Service.Aliases,
+			"name":        c.Service.Name,
+			"description": c.Service.Description,
+			"framework":   c.Service.Framework,
+			"language":    c.Service.Language,
+			"product":     c.Service.Product,
+			"owner":       c.Service.Owner.Alias,
+			"tier":        c.Service.Tier.Alias,
+			"lifecycle":   c.Service.Lifecycle.Alias,
+			"htmlUrl":     c.Service.HtmlURL,
+		}
+	}
+	if c.User != nil {
+		output["user"] = map[string]any{
+			"id":    string(c.User.Id),
+			"name":  c.User.Name,
+			"email": c.User.Email,
+			"role":  string(c.User.Role),
+		}
+	}
+	manualInputs := map[string]any{}
+	for key, value := range c.ManualInputs {
+		manualInputs[key] = value
+	}
+	output["manualInputs"] = manualInputs
+	secrets := map[string]any{}
+	for _, alias := range c.Secrets {
+		secrets[alias] = SecretPlaceholder(alias)
+	}
+	output["secrets"] = secrets
+	for key, value := range c.Extra {
+		output[key] = value
+	}
+	return output
+}
+
+// PreviewLiquidTemplate renders 'template' against the sample 'context' reporting syntax errors and undefined variables
+func PreviewLiquidTemplate(template string, context LiquidPreviewContext) *LiquidPreview {
+	output := &LiquidPreview{}
+	engine := liquid.NewEngine()
+	// Mirror the 'json' filter available to custom action templates
+	engine.RegisterFilter("json", func(value any) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	})
+	tpl, err := engine.ParseTemplateLocation([]byte(template), "", 1)
+	if err != nil {
+		output.SyntaxErrors = append(output.SyntaxErrors, LiquidTemplateIssue{Line: err.LineNumber(), Message: err.Error()})
+		return output
+	}
+	bindings := context.Bindings()
+
+	defined := map[string]bool{}
+	collectLiquidDefinitions(tpl.GetRoot(), defined)
+	findUndefinedLiquidVariables(tpl.GetRoot(), bindings, defined, map[string]bool{}, output)
+
+	rendered, err := tpl.RenderString(bindings)
+	if err != nil {
+		output.SyntaxErrors = append(output.SyntaxErrors, LiquidTemplateIssue{Line: err.LineNumber(), Message: err.Error()})
+		return output
+	}
+	output.Output = rendered
+	return output
+}
+
+func (a *CustomActionsExternalAction) PreviewLiquidTemplate(context LiquidPreviewContext) *LiquidPreview {
+	return PreviewLiquidTemplate(a.LiquidTemplate, context)
+}
+
+func (t *CustomActionsTriggerDefinition) PreviewResponseTemplate(context LiquidPreviewContext) *LiquidPreview {
+	return PreviewLiquidTemplate(t.ResponseTemplate, context)
+}
+
+// collectLiquidDefinitions finds every variable the template defines itself via assign, capture, increment or decrement
+func collectLiquidDefinitions(node render.Node, defined map[string]bool) {
+	switch n := node.(type) {
+	case *render.SeqNode:
+		for _, child := range n.Children {
+			collectLiquidDefinitions(child, defined)
+		}
+	case *render.TagNode:
+		switch n.Name {
+		case "assign", "increment", "decrement":
+			if match := liquidAssignment.FindStringSubmatch(n.Args); match != nil {
+				defined[match[1]] = true
+			}
+		}
+	case *render.BlockNode:
+		if n.Name == "capture" {
+			if match := liquidAssignment.FindStringSubmatch(n.Args); match != nil {
+				defined[match[1]] = true
+			}
+		}
+		for _, child := range n.Body {
+			collectLiquidDefinitions(child, defined)
+		}
+		for _, clause := range n.Clauses {
+			collectLiquidDefinitions(clause, defined)
+		}
+	}
+}
+
+func findUndefinedLiquidVariables(node render.Node, bindings map[string]any, defined map[string]bool, scope map[string]bool, output *LiquidPreview) {
+	switch n := node.(type) {
+	case *render.SeqNode:
+		for _, child := range n.Children {
+			findUndefinedLiquidVariables(child, bindings, defined, scope, output)
+		}
+	case *render.ObjectNode:
+		match := liquidVariablePath.FindStringSubmatch(n.Args)
+		if match == nil {
+			return
+		}
+		segments := liquidPathSegment.FindAllString(match[1], -1)
+		if liquidKeywords[segments[0]] || defined[segments[0]] || scope[segments[0]] {
+			return
+		}
+		if !resolveLiquidPath(bindings, segments) {
+			output.UndefinedVariables = append(output.UndefinedVariables, LiquidTemplateIssue{
+				Line:    n.SourceLoc.LineNo,
+				Message: fmt.Sprintf("undefined variable '%s'", match[1]),
+			})
+		}
+	case *render.BlockNode:
+		inner := scope
+		switch n.Name {
+		case "for", "tablerow":
+			if match := liquidLoopVariable.FindStringSubmatch(n.Args); match != nil {
+				inner = map[string]bool{"forloop": true, "tablerowloop": true, match[1]: true}
+				for key := range scope {
+					inner[key] = true
+				}
+			}
+		}
+		for _, child := range n.Body {
+			findUndefinedLiquidVariables(child, bindings, defined, inner, output)
+		}
+		for _, clause := range n.Clauses {
+			findUndefinedLiquidVariables(clause, bindings, defined, inner, output)
+		}
+	}
+}
+
+// resolveLiquidPath returns true if every segment of a variable path like 'service.aliases[0]' exists in 'bindings'
+func resolveLiquidPath(bindings map[string]any, segments []string) bool {
+	var current any = bindings
+	for _, segment := range segments {
+		if current == nil {
+			return false
+		}
+		if strings.HasPrefix(segment, "[") {
+			index, err := strconv.Atoi(strings.Trim(segment, "[]"))
+			if err != nil {
+				return false
+			}
+			switch list := current.(type) {
+			case []any:
+				if index >= len(list) {
+					return false
+				}
+				current = list[index]
+			case []string:
+				if index >= len(list) {
+					return false
+				}
+				current = list[index]
+			default:
+				return false
+			}
+			continue
+		}
+		switch value := current.(type) {
+		case map[string]any:
+			next, ok := value[segment]
+			if !ok {
+				return liquidBuiltinProperties[segment]
+			}
+			current = next
+		default:
+			// Arrays and strings only expose liquid's builtin properties
+			return liquidBuiltinProperties[segment]
+		}
+	}
+	return current != nil
+}
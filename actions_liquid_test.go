@@ -0,0 +1,58 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+var liquidPreviewContext = ol.LiquidPreviewContext{
+	Service:      &ol.Service{ServiceId: ol.ServiceId{Id: id1, Aliases: []string{"api", "api_service"}}, Name: "API"},
+	User:         &ol.User{UserId: ol.UserId{Id: id2, Email: "kyle@opslevel.com"}, Name: "Kyle"},
+	ManualInputs: map[string]any{"environment": "staging", "replicas": 3},
+	Secrets:      []string{"deploy_token"},
+}
+
+func TestPreviewLiquidTemplate(t *testing.T) {
+	// Arrange
+	action := ol.CustomActionsExternalAction{
+		LiquidTemplate: `{"service": "{{ service.alias }}", "env": "{{ manualInputs.environment | upcase }}", "token": "{{ secrets.deploy_token }}", "by": "{{ user.email }}"{% for alias in service.aliases %}, "{{ alias }}": {{ forloop.index }}{% endfor %}}`,
+	}
+	// Act
+	result := action.PreviewLiquidTemplate(liquidPreviewContext)
+	// Assert
+	autopilot.Ok(t, result.Err())
+	autopilot.Equals(t, `{"service": "api", "env": "STAGING", "token": "<secret:deploy_token>", "by": "kyle@opslevel.com", "api": 1, "api_service": 2}`, result.Output)
+}
+
+func TestPreviewLiquidTemplateUndefinedVariables(t *testing.T) {
+	// Arrange
+	template := `{% assign region = "us" %}{{ region }}
+{{ manualInputs.missing }}
+{{ service.alias }} {{ services.name }} {{ service.aliases.size }}`
+	// Act
+	result := ol.PreviewLiquidTemplate(template, liquidPreviewContext)
+	// Assert
+	autopilot.Equals(t, 0, len(result.SyntaxErrors))
+	autopilot.Equals(t, []ol.LiquidTemplateIssue{
+		{Line: 2, Message: "undefined variable 'manualInputs.missing'"},
+		{Line: 3, Message: "undefined variable 'services.name'"},
+	}, result.UndefinedVariables)
+	autopilot.Equals(t, false, result.Ok())
+}
+
+func TestPreviewLiquidTemplateSyntaxError(t *testing.T) {
+	// Arrange
+	trigger := ol.CustomActionsTriggerDefinition{
+		ResponseTemplate: "{% if response.status == 200 %}\nDeployed\n",
+	}
+	// Act
+	result := trigger.PreviewResponseTemplate(ol.LiquidPreviewContext{
+		Extra: map[string]any{"response": map[string]any{"status": 200}},
+	})
+	// Assert
+	autopilot.Equals(t, 1, len(result.SyntaxErrors))
+	autopilot.Equals(t, 1, result.SyntaxErrors[0].Line)
+	autopilot.Assert(t, result.Err() != nil, "expected an error for an unclosed if tag")
+}
@@ -0,0 +1,245 @@
+package opslevel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ManualInputType string
+
+const (
+	ManualInputTypeTextInput ManualInputType = "text_input"
+	ManualInputTypeTextArea  ManualInputType = "text_area"
+	ManualInputTypeDropdown  ManualInputType = "dropdown"
+	ManualInputTypeCheckbox  ManualInputType = "checkbox"
+)
+
+// All ManualInputType as []string
+var AllManualInputType = []string{
+	string(ManualInputTypeTextInput),
+	string(ManualInputTypeTextArea),
+	string(ManualInputTypeDropdown),
+	string(ManualInputTypeCheckbox),
+}
+
+// ManualInput is a single field a user fills in when manually invoking a trigger definition
+type ManualInput struct {
+	Identifier   string          `json:"identifier" yaml:"identifier"`
+	DisplayName  string          `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+	Description  string          `json:"description,omitempty" yaml:"description,omitempty"`
+	Type         ManualInputType `json:"type" yaml:"type"`
+	Required     bool            `json:"required,omitempty" yaml:"required,omitempty"`
+	DefaultValue any             `json:"defaultValue,omitempty" yaml:"defaultValue,omitempty"`
+	MaxLength    int             `json:"maxLength,omitempty" yaml:"maxLength,omitempty"` // Only for text_input and text_area
+	Values       []string        `json:"values,omitempty" yaml:"values,omitempty"`       // Options of a dropdown
+}
+
+// ManualInputsDefinition is the typed model of CustomActionsTriggerDefinition.ManualInputsDefinition
+type ManualInputsDefinition struct {
+	Version int           `json:"version" yaml:"version"`
+	Inputs  []ManualInput `json:"inputs" yaml:"inputs"`
+}
+
+type ManualInputsError struct {
+	Field   string
+	Message string
+}
+
+func (e ManualInputsError) String() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ManualInputsErrors collects every problem found in a definition or a set of submitted values
+type ManualInputsErrors []ManualInputsError
+
+func (e ManualInputsErrors) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.String())
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (e ManualInputsErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ParseManualInputsDefinition parses and validates a manual inputs definition YAML string
+func ParseManualInputsDefinition(definition string) (*ManualInputsDefinition, error) {
+	var output ManualInputsDefinition
+	if err := yaml.Unmarshal([]byte(definition), &output); err != nil {
+		return nil, fmt.Errorf("invalid manual inputs definition: %w", err)
+	}
+	if err := output.Validate(); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// Marshal returns the YAML string to pass as the ManualInputsDefinition of a trigger definition input
+func (d *ManualInputsDefinition) Marshal() (string, error) {
+	if err := d.Validate(); err != nil {
+		return "", err
+	}
+	data, err := yaml.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (d *ManualInputsDefinition) Get(identifier string) *ManualInput {
+	for i, input := range d.Inputs {
+		if input.Identifier == identifier {
+			return &d.Inputs[i]
+		}
+	}
+	return nil
+}
+
+// Defaults returns the default value of each input that has one
+func (d *ManualInputsDefinition) Defaults() map[string]any {
+	output := map[string]any{}
+	for _, input := range d.Inputs {
+		if input.DefaultValue != nil {
+			output[input.Identifier] = input.DefaultValue
+		}
+	}
+	return output
+}
+
+func (d *ManualInputsDefinition) Validate() error {
+	var errs ManualInputsErrors
+	if d.Version != 1 {
+		errs = append(errs, ManualInputsError{Field: "version", Message: fmt.Sprintf("unsupported version '%d' - expected 1", d.Version)})
+	}
+	seen := map[string]bool{}
+	for i, input := range d.Inputs {
+		field := fmt.Sprintf("inputs[%d]", i)
+		if input.Identifier == "" {
+			errs = append(errs, ManualInputsError{Field: field, Message: "identifier is required"})
+		} else {
+			field = input.Identifier
+			if seen[input.Identifier] {
+				errs = append(errs, ManualInputsError{Field: field, Message: "duplicate identifier"})
+			}
+			seen[input.Identifier] = true
+		}
+		errs = append(errs, input.validate(field)...)
+	}
+	return errs.orNil()
+}
+
+func (i *ManualInput) validate(field string) ManualInputsErrors {
+	var errs ManualInputsErrors
+	switch i.Type {
+	case ManualInputTypeTextInput, ManualInputTypeTextArea, ManualInputTypeCheckbox:
+		if len(i.Values) > 0 {
+			errs = append(errs, ManualInputsError{Field: field, Message: fmt.Sprintf("values are only supported on type '%s'", ManualInputTypeDropdown)})
+		}
+	case ManualInputTypeDropdown:
+		if len(i.Values) == 0 {
+			errs = append(errs, ManualInputsError{Field: field, Message: "dropdown requires at least one value"})
+		}
+	case "":
+		return append(errs, ManualInputsError{Field: field, Message: "type is required"})
+	default:
+		return append(errs, ManualInputsError{Field: field, Message: fmt.Sprintf("unknown type '%s' - expected one of %s", i.Type, strings.Join(AllManualInputType, ", "))})
+	}
+	if i.MaxLength < 0 {
+		errs = append(errs, ManualInputsError{Field: field, Message: "maxLength cannot be negative"})
+	}
+	if i.MaxLength > 0 && i.Type != ManualInputTypeTextInput && i.Type != ManualInputTypeTextArea {
+		errs = append(errs, ManualInputsError{Field: field, Message: "maxLength is only supported on text inputs"})
+	}
+	if i.DefaultValue != nil {
+		if err := i.check(i.DefaultValue); err != "" {
+			errs = append(errs, ManualInputsError{Field: field, Message: "invalid defaultValue: " + err})
+		}
+	}
+	return errs
+}
+
+// check returns a description of why 'value' is not acceptable for the input or an empty string if it is
+func (i *ManualInput) check(value any) string {
+	switch i.Type {
+	case ManualInputTypeCheckbox:
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("expected a boolean got %T", value)
+		}
+	case ManualInputTypeDropdown:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected a string got %T", value)
+		}
+		for _, option := range i.Values {
+			if option == text {
+				return ""
+			}
+		}
+		return fmt.Sprintf("'%s' is not one of %s", text, strings.Join(i.Values, ", "))
+	default:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected a string got %T", value)
+		}
+		if i.MaxLength > 0 && len(text) > i.MaxLength {
+			return fmt.Sprintf("length %d exceeds maxLength %d", len(text), i.MaxLength)
+		}
+	}
+	return ""
+}
+
+// ValidateInputs checks user submitted 'values' against the definition and returns them with defaults applied
+func (d *ManualInputsDefinition) ValidateInputs(values map[string]any) (map[string]any, error) {
+	var errs ManualInputsErrors
+	var unknown []string
+	for key := range values {
+		if d.Get(key) == nil {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		errs = append(errs, ManualInputsError{Field: key, Message: "unknown input"})
+	}
+	output := map[string]any{}
+	for _, input := range d.Inputs {
+		value, ok := values[input.Identifier]
+		if !ok || value == nil {
+			value = input.DefaultValue
+		}
+		if value == nil || value == "" {
+			if input.Required {
+				errs = append(errs, ManualInputsError{Field: input.Identifier, Message: "is required"})
+			}
+			continue
+		}
+		if err := input.check(value); err != "" {
+			errs = append(errs, ManualInputsError{Field: input.Identifier, Message: err})
+			continue
+		}
+		output[input.Identifier] = value
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return output, nil
+}
+
+// GetManualInputs returns the typed manual inputs of the trigger definition - empty if it has none
+func (t *CustomActionsTriggerDefinition) GetManualInputs() (*ManualInputsDefinition, error) {
+	if strings.TrimSpace(t.ManualInputsDefinition) == "" {
+		return &ManualInputsDefinition{Version: 1}, nil
+	}
+	return ParseManualInputsDefinition(t.ManualInputsDefinition)
+}
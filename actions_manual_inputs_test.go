@@ -0,0 +1,89 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+const manualInputsDefinition = `version: 1
+inputs:
+  - identifier: title
+    displayName: Title
+    type: text_input
+    required: true
+    maxLength: 10
+  - identifier: environment
+    type: dropdown
+    values: [staging, production]
+    defaultValue: staging
+  - identifier: notify
+    type: checkbox
+    defaultValue: false
+`
+
+func TestParseManualInputsDefinition(t *testing.T) {
+	// Arrange
+	trigger := ol.CustomActionsTriggerDefinition{ManualInputsDefinition: manualInputsDefinition}
+	// Act
+	result, err := trigger.GetManualInputs()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 3, len(result.Inputs))
+	autopilot.Equals(t, ol.ManualInputTypeDropdown, result.Get("environment").Type)
+	autopilot.Equals(t, []string{"staging", "production"}, result.Get("environment").Values)
+	autopilot.Equals(t, map[string]any{"environment": "staging", "notify": false}, result.Defaults())
+}
+
+func TestParseManualInputsDefinitionInvalid(t *testing.T) {
+	// Arrange
+	definition := `version: 2
+inputs:
+  - identifier: title
+    type: text_input
+    values: [a]
+  - identifier: title
+    type: dropdown
+  - type: slider
+`
+	// Act
+	_, err := ol.ParseManualInputsDefinition(definition)
+	// Assert
+	autopilot.Equals(t, ol.ManualInputsErrors{
+		{Field: "version", Message: "unsupported version '2' - expected 1"},
+		{Field: "title", Message: "values are only supported on type 'dropdown'"},
+		{Field: "title", Message: "duplicate identifier"},
+		{Field: "title", Message: "dropdown requires at least one value"},
+		{Field: "inputs[2]", Message: "identifier is required"},
+		{Field: "inputs[2]", Message: "unknown type 'slider' - expected one of text_input, text_area, dropdown, checkbox"},
+	}, err)
+}
+
+func TestValidateManualInputs(t *testing.T) {
+	// Arrange
+	definition, _ := ol.ParseManualInputsDefinition(manualInputsDefinition)
+	// Act
+	result, err := definition.ValidateInputs(map[string]any{"title": "Rollback", "notify": true})
+	_, errs := definition.ValidateInputs(map[string]any{"title": "A very long title", "environment": "dev", "extra": 1})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, map[string]any{"title": "Rollback", "environment": "staging", "notify": true}, result)
+	autopilot.Equals(t, ol.ManualInputsErrors{
+		{Field: "extra", Message: "unknown input"},
+		{Field: "title", Message: "length 17 exceeds maxLength 10"},
+		{Field: "environment", Message: "'dev' is not one of staging, production"},
+	}, errs)
+}
+
+func TestCreateTriggerDefinitionInvalidManualInputs(t *testing.T) {
+	// Arrange
+	client := ol.NewGQLClient(ol.SetAPIToken("x"), ol.SetURL("http://localhost:0"))
+	// Act
+	_, err := client.CreateTriggerDefinition(ol.CustomActionsTriggerDefinitionCreateInput{
+		Name:                   "Deploy Rollback",
+		ManualInputsDefinition: "version: 1\ninputs:\n  - identifier: title\n",
+	})
+	// Assert
+	autopilot.Equals(t, "title: type is required", err.Error())
+}
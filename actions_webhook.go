@@ -0,0 +1,176 @@
+package opslevel
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const (
+	DefaultCustomActionsWebhookSecretHeader = "X-OpsLevel-Webhook-Secret"
+	DefaultCustomActionsWebhookMaxBodyBytes = 1 << 20
+)
+
+// CustomActionsWebhookPayload is the body a receiver expects a custom action to POST.
+// Use CustomActionsWebhookPayloadTemplate as the action's LiquidTemplate to produce it.
+type CustomActionsWebhookPayload struct {
+	Action            CustomActionsWebhookPayloadRef    `json:"action"`
+	TriggerDefinition CustomActionsWebhookPayloadRef    `json:"triggerDefinition"`
+	Entity            CustomActionsWebhookPayloadEntity `json:"entity"`
+	User              CustomActionsWebhookPayloadUser   `json:"user"`
+	ManualInputs      map[string]any                    `json:"manualInputs"`
+}
+
+type CustomActionsWebhookPayloadRef struct {
+	Id    ID     `json:"id,omitempty"`
+	Alias string `json:"alias"`
+	Name  string `json:"name,omitempty"`
+}
+
+type CustomActionsWebhookPayloadEntity struct {
+	Id    ID                          `json:"id"`
+	Alias string                      `json:"alias"`
+	Name  string                      `json:"name"`
+	Type  CustomActionsEntityTypeEnum `json:"type"`
+}
+
+type CustomActionsWebhookPayloadUser struct {
+	Id    ID     `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// CustomActionsWebhookPayloadTemplate returns a LiquidTemplate that renders the payload CustomActionsWebhookReceiver decodes
+func CustomActionsWebhookPayloadTemplate(actionAlias string, triggerAlias string) string {
+	action, _ := json.Marshal(CustomActionsWebhookPayloadRef{Alias: actionAlias})
+	trigger, _ := json.Marshal(CustomActionsWebhookPayloadRef{Alias: triggerAlias})
+	return fmt.Sprintf(`{
+  "action": %s,
+  "triggerDefinition": %s,
+  "entity": {"id": {{ service.id | json }}, "alias": {{ service.alias | json }}, "name": {{ service.name | json }}, "type": "SERVICE"},
+  "user": {"id": {{ user.id | json }}, "name": {{ user.name | json }}, "email": {{ user.email | json }}},
+  "manualInputs": {{ manualInputs | json }}
+}`, action, trigger)
+}
+
+// CustomActionsWebhookResponse is written back to OpsLevel as JSON and is available to a
+// trigger definition's ResponseTemplate as 'response.status' and 'response.body'
+type CustomActionsWebhookResponse struct {
+	StatusCode int            `json:"-"`
+	Message    string         `json:"message"`
+	Data       map[string]any `json:"data,omitempty"`
+}
+
+// TemplateContext returns the 'response' variable a ResponseTemplate sees for this response, useful with PreviewLiquidTemplate
+func (r *CustomActionsWebhookResponse) TemplateContext() map[string]any {
+	body := map[string]any{"message": r.Message}
+	if len(r.Data) > 0 {
+		body["data"] = r.Data
+	}
+	return map[string]any{
+		"status": r.StatusCode,
+		"body":   body,
+	}
+}
+
+type CustomActionsWebhookHandlerFunc func(ctx context.Context, payload *CustomActionsWebhookPayload) (*CustomActionsWebhookResponse, error)
+
+// CustomActionsWebhookReceiver is an http.Handler that verifies, decodes and routes custom action webhooks by action alias
+type CustomActionsWebhookReceiver struct {
+	Secret       string // Expected value of SecretHeader - every request is rejected when empty
+	SecretHeader string // Header configured in the CustomActionsWebhookAction.Headers - defaults to DefaultCustomActionsWebhookSecretHeader
+	MaxBodyBytes int64  // Larger payloads are rejected - defaults to DefaultCustomActionsWebhookMaxBodyBytes
+
+	mutex    sync.RWMutex
+	handlers map[string]CustomActionsWebhookHandlerFunc
+}
+
+func NewCustomActionsWebhookReceiver(secret string) *CustomActionsWebhookReceiver {
+	return &CustomActionsWebhookReceiver{
+		Secret:       secret,
+		SecretHeader: DefaultCustomActionsWebhookSecretHeader,
+		MaxBodyBytes: DefaultCustomActionsWebhookMaxBodyBytes,
+		handlers:     map[string]CustomActionsWebhookHandlerFunc{},
+	}
+}
+
+// Handle registers the handler invoked for payloads of the custom action with alias 'actionAlias'
+func (r *CustomActionsWebhookReceiver) Handle(actionAlias string, handler CustomActionsWebhookHandlerFunc) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.handlers == nil {
+		r.handlers = map[string]CustomActionsWebhookHandlerFunc{}
+	}
+	r.handlers[actionAlias] = handler
+}
+
+func (r *CustomActionsWebhookReceiver) verify(req *http.Request) bool {
+	if r.Secret == "" {
+		return false
+	}
+	header := r.SecretHeader
+	if header == "" {
+		header = DefaultCustomActionsWebhookSecretHeader
+	}
+	return subtle.ConstantTimeCompare([]byte(req.Header.Get(header)), []byte(r.Secret)) == 1
+}
+
+func (r *CustomActionsWebhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeCustomActionsWebhookResponse(w, &CustomActionsWebhookResponse{StatusCode: http.StatusMethodNotAllowed, Message: fmt.Sprintf("method '%s' not allowed", req.Method)})
+		return
+	}
+	if !r.verify(req) {
+		writeCustomActionsWebhookResponse(w, &CustomActionsWebhookResponse{StatusCode: http.StatusUnauthorized, Message: "invalid webhook secret"})
+		return
+	}
+	limit := r.MaxBodyBytes
+	if limit <= 0 {
+		limit = DefaultCustomActionsWebhookMaxBodyBytes
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, req.Body, limit))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeCustomActionsWebhookResponse(w, &CustomActionsWebhookResponse{StatusCode: http.StatusRequestEntityTooLarge, Message: fmt.Sprintf("payload exceeds %d bytes", limit)})
+			return
+		}
+		writeCustomActionsWebhookResponse(w, &CustomActionsWebhookResponse{StatusCode: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	var payload CustomActionsWebhookPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		writeCustomActionsWebhookResponse(w, &CustomActionsWebhookResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("invalid payload: %s", err)})
+		return
+	}
+	r.mutex.RLock()
+	handler, ok := r.handlers[payload.Action.Alias]
+	r.mutex.RUnlock()
+	if !ok {
+		writeCustomActionsWebhookResponse(w, &CustomActionsWebhookResponse{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("no handler registered for action '%s'", payload.Action.Alias)})
+		return
+	}
+	response, err := handler(req.Context(), &payload)
+	if err != nil {
+		writeCustomActionsWebhookResponse(w, &CustomActionsWebhookResponse{StatusCode: http.StatusInternalServerError, Message: err.Error()})
+		return
+	}
+	if response == nil {
+		response = &CustomActionsWebhookResponse{Message: "ok"}
+	}
+	writeCustomActionsWebhookResponse(w, response)
+}
+
+func writeCustomActionsWebhookResponse(w http.ResponseWriter, response *CustomActionsWebhookResponse) {
+	if response.StatusCode == 0 {
+		response.StatusCode = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+	_ = json.NewEncoder(w).Encode(response)
+}
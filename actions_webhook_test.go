@@ -0,0 +1,128 @@
+package opslevel_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func newTestWebhookReceiver() *ol.CustomActionsWebhookReceiver {
+	receiver := ol.NewCustomActionsWebhookReceiver("s3cr3t")
+	receiver.Handle("rollback", func(ctx context.Context, payload *ol.CustomActionsWebhookPayload) (*ol.CustomActionsWebhookResponse, error) {
+		if payload.ManualInputs["environment"] == "production" {
+			return nil, fmt.Errorf("production rollbacks are disabled")
+		}
+		return &ol.CustomActionsWebhookResponse{
+			Message: fmt.Sprintf("rolled back %s for %s", payload.Entity.Alias, payload.User.Email),
+			Data:    map[string]any{"environment": payload.ManualInputs["environment"]},
+		}, nil
+	})
+	return receiver
+}
+
+func sendTestWebhook(receiver http.Handler, secret string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	request.Header.Set(ol.DefaultCustomActionsWebhookSecretHeader, secret)
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestCustomActionsWebhookReceiver(t *testing.T) {
+	// Arrange
+	preview := ol.PreviewLiquidTemplate(ol.CustomActionsWebhookPayloadTemplate("rollback", "rollback_trigger"), liquidPreviewContext)
+	autopilot.Ok(t, preview.Err())
+	// Act
+	result := sendTestWebhook(newTestWebhookReceiver(), "s3cr3t", preview.Output)
+	// Assert
+	autopilot.Equals(t, http.StatusOK, result.Code)
+	autopilot.Equals(t, `{"message":"rolled back api for kyle@opslevel.com","data":{"environment":"staging"}}`, strings.TrimSpace(result.Body.String()))
+}
+
+func TestCustomActionsWebhookReceiverErrors(t *testing.T) {
+	// Arrange
+	receiver := newTestWebhookReceiver()
+	// Act
+	unauthorized := sendTestWebhook(receiver, "wrong", `{"action": {"alias": "rollback"}}`)
+	invalid := sendTestWebhook(receiver, "s3cr3t", `{"action": `)
+	unknown := sendTestWebhook(receiver, "s3cr3t", `{"action": {"alias": "deploy"}}`)
+	failed := sendTestWebhook(receiver, "s3cr3t", `{"action": {"alias": "rollback"}, "manualInputs": {"environment": "production"}}`)
+	// Assert
+	autopilot.Equals(t, http.StatusUnauthorized, unauthorized.Code)
+	autopilot.Equals(t, http.StatusBadRequest, invalid.Code)
+	autopilot.Equals(t, http.StatusNotFound, unknown.Code)
+	autopilot.Equals(t, http.StatusInternalServerError, failed.Code)
+	autopilot.Equals(t, `{"message":"production rollbacks are disabled"}`, strings.TrimSpace(failed.Body.String()))
+}
+
+func TestCustomActionsWebhookReceiverRejectsEmptySecret(t *testing.T) {
+	// Arrange
+	receiver := ol.NewCustomActionsWebhookReceiver("")
+	receiver.Handle("rollback", func(ctx context.Context, payload *ol.CustomActionsWebhookPayload) (*ol.CustomActionsWebhookResponse, error) {
+		return nil, nil
+	})
+	// Act
+	result := sendTestWebhook(receiver, "", `{"action": {"alias": "rollback"}}`)
+	// Assert
+	autopilot.Equals(t, http.StatusUnauthorized, result.Code)
+}
+
+func TestCustomActionsWebhookReceiverMaxBodyBytes(t *testing.T) {
+	// Arrange
+	receiver := newTestWebhookReceiver()
+	receiver.MaxBodyBytes = 16
+	// Act
+	result := sendTestWebhook(receiver, "s3cr3t", `{"action": {"alias": "rollback"}}`)
+	// Assert
+	autopilot.Equals(t, http.StatusRequestEntityTooLarge, result.Code)
+	autopilot.Equals(t, `{"message":"payload exceeds 16 bytes"}`, strings.TrimSpace(result.Body.String()))
+}
+
+func TestCustomActionsWebhookPayloadTemplateEscapesValues(t *testing.T) {
+	// Arrange
+	template := ol.CustomActionsWebhookPayloadTemplate("rollback", "rollback_trigger")
+	context := liquidPreviewContext
+	context.Service = &ol.Service{ServiceId: ol.ServiceId{Id: id1, Aliases: []string{"api"}}, Name: `The "API", v2`}
+	// Act
+	preview := ol.PreviewLiquidTemplate(template, context)
+	var payload ol.CustomActionsWebhookPayload
+	err := json.Unmarshal([]byte(preview.Output), &payload)
+	// Assert
+	autopilot.Ok(t, preview.Err())
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, `The "API", v2`, payload.Entity.Name)
+}
+
+func TestCustomActionsWebhookPayloadTemplateEscapesAliases(t *testing.T) {
+	// Arrange
+	template := ol.CustomActionsWebhookPayloadTemplate("roll\vback", `"rollback"`)
+	// Act
+	preview := ol.PreviewLiquidTemplate(template, liquidPreviewContext)
+	var payload ol.CustomActionsWebhookPayload
+	err := json.Unmarshal([]byte(preview.Output), &payload)
+	// Assert
+	autopilot.Ok(t, preview.Err())
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "roll\vback", payload.Action.Alias)
+	autopilot.Equals(t, `"rollback"`, payload.TriggerDefinition.Alias)
+}
+
+func TestCustomActionsWebhookResponseTemplate(t *testing.T) {
+	// Arrange
+	response := ol.CustomActionsWebhookResponse{StatusCode: 200, Message: "done"}
+	trigger := ol.CustomActionsTriggerDefinition{
+		ResponseTemplate: `{% if response.status == 200 %}Success: {{ response.body.message }}{% else %}Failed{% endif %}`,
+	}
+	// Act
+	result := trigger.PreviewResponseTemplate(ol.LiquidPreviewContext{Extra: map[string]any{"response": response.TemplateContext()}})
+	// Assert
+	autopilot.Ok(t, result.Err())
+	autopilot.Equals(t, "Success: done", result.Output)
+}
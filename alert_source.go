@@ -1,5 +1,13 @@
 package opslevel
 
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+const alertSourceServicesChunkSize = 20
+
 type AlertSourceExternalIdentifier struct {
 	Type       AlertSourceTypeEnum `json:"type"`
 	ExternalId string              `json:"externalId"`
@@ -22,6 +30,18 @@ type AlertSourceService struct {
 	Status      AlertSourceStatusTypeEnum `graphql:"status"`
 }
 
+type AlertSourceConnection struct {
+	Nodes      []AlertSource
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type AlertSourceServiceConnection struct {
+	Nodes      []AlertSourceService
+	PageInfo   PageInfo
+	TotalCount int
+}
+
 type AlertSourceServiceCreateInput struct {
 	Service    IdentifierInput                `json:"service"`
 	Id         ID                             `json:"alertSourceId,omitempty"`
@@ -88,6 +108,121 @@ func (client *Client) GetAlertSource(id ID) (*AlertSource, error) {
 	return &q.Account.AlertSource, HandleErrors(err, nil)
 }
 
+func (client *Client) ListAlertSources(variables *PayloadVariables) (*AlertSourceConnection, error) {
+	var q struct {
+		Account struct {
+			AlertSources AlertSourceConnection `graphql:"alertSources(after: $after, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	if err := client.Query(&q, *variables, WithName("AlertSourceList")); err != nil {
+		return nil, err
+	}
+	for q.Account.AlertSources.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.AlertSources.PageInfo.End
+		resp, err := client.ListAlertSources(variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.AlertSources.Nodes = append(q.Account.AlertSources.Nodes, resp.Nodes...)
+		q.Account.AlertSources.PageInfo = resp.PageInfo
+		q.Account.AlertSources.TotalCount += resp.TotalCount
+	}
+	return &q.Account.AlertSources, nil
+}
+
+// OfType returns the alert sources in the connection with one of 'types' - all of them when no types are given
+func (c *AlertSourceConnection) OfType(types ...AlertSourceTypeEnum) []AlertSource {
+	if len(types) == 0 {
+		return c.Nodes
+	}
+	var output []AlertSource
+	for _, source := range c.Nodes {
+		if slices.Contains(types, source.Type) {
+			output = append(output, source)
+		}
+	}
+	return output
+}
+
+func (s *Service) GetAlertSources(client *Client, variables *PayloadVariables) (*AlertSourceServiceConnection, error) {
+	var q struct {
+		Account struct {
+			Service struct {
+				AlertSources AlertSourceServiceConnection `graphql:"alertSources(after: $after, first: $first)"`
+			} `graphql:"service(id: $service)"`
+		}
+	}
+	if s.Id == "" {
+		return nil, fmt.Errorf("Unable to get AlertSources, invalid service id: '%s'", s.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["service"] = s.Id
+	if err := client.Query(&q, *variables, WithName("ServiceAlertSourcesList")); err != nil {
+		return nil, err
+	}
+	for q.Account.Service.AlertSources.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Service.AlertSources.PageInfo.End
+		resp, err := s.GetAlertSources(client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Service.AlertSources.Nodes = append(q.Account.Service.AlertSources.Nodes, resp.Nodes...)
+		q.Account.Service.AlertSources.PageInfo = resp.PageInfo
+		q.Account.Service.AlertSources.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Service.AlertSources, nil
+}
+
+// ListAlertSourceServices returns the alert source links of all the 'services', looking them up in batches
+// instead of one request per service
+func (client *Client) ListAlertSourceServices(services ...ID) ([]AlertSourceService, error) {
+	type node struct {
+		AlertSources AlertSourceServiceConnection `graphql:"alertSources(first: $first)"`
+	}
+	var output []AlertSourceService
+	for start := 0; start < len(services); start += alertSourceServicesChunkSize {
+		chunk := services[start:min(start+alertSourceServicesChunkSize, len(services))]
+		fields := make([]reflect.StructField, len(chunk))
+		v := PayloadVariables{"first": client.pageSize}
+		for i, id := range chunk {
+			name := fmt.Sprintf("s%d", i)
+			v[name] = id
+			fields[i] = reflect.StructField{
+				Name: fmt.Sprintf("S%d", i),
+				Type: reflect.TypeOf(node{}),
+				Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"%s: service(id: $%s)"`, name, name)),
+			}
+		}
+		q := reflect.New(reflect.StructOf([]reflect.StructField{{
+			Name: "Account",
+			Type: reflect.StructOf(fields),
+		}}))
+		if err := client.Query(q.Interface(), v, WithName("ServicesAlertSourcesList")); err != nil {
+			return nil, err
+		}
+		account := q.Elem().Field(0)
+		for i, id := range chunk {
+			links := account.Field(i).Interface().(node).AlertSources
+			output = append(output, links.Nodes...)
+			if !links.PageInfo.HasNextPage {
+				continue
+			}
+			service := Service{ServiceId: ServiceId{Id: id}}
+			rest, err := service.GetAlertSources(client, &PayloadVariables{"after": links.PageInfo.End, "first": client.pageSize})
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, rest.Nodes...)
+		}
+	}
+	return output, nil
+}
+
 //#endregion
 
 //#region delete
@@ -0,0 +1,242 @@
+package opslevel
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type AlertSourceMatch string
+
+const (
+	AlertSourceMatchAlias AlertSourceMatch = "alias" // A service alias equals the value once both are normalized with NormalizeAlias
+	AlertSourceMatchName  AlertSourceMatch = "name"  // The service name equals the value ignoring case
+	AlertSourceMatchTag   AlertSourceMatch = "tag"   // The service has a tag with TagKey whose value equals the value or the alert source external id
+)
+
+// AlertSourceMappingRule matches alert sources to services. The value matched is the alert source name or,
+// when Pattern is set, its 'value' named group, first capture group or whole match.
+type AlertSourceMappingRule struct {
+	Match   AlertSourceMatch
+	Pattern *regexp.Regexp
+	TagKey  string
+	Types   []AlertSourceTypeEnum // Only apply the rule to these alert source types - all when empty
+}
+
+// DefaultAlertSourceMappingRules matches alert sources named after a service alias, then after a service name
+func DefaultAlertSourceMappingRules() []AlertSourceMappingRule {
+	return []AlertSourceMappingRule{
+		{Match: AlertSourceMatchAlias},
+		{Match: AlertSourceMatchName},
+	}
+}
+
+func (r *AlertSourceMappingRule) value(source AlertSource) (string, bool) {
+	if len(r.Types) > 0 && !slices.Contains(r.Types, source.Type) {
+		return "", false
+	}
+	if r.Pattern == nil {
+		return source.Name, source.Name != ""
+	}
+	match := r.Pattern.FindStringSubmatch(source.Name)
+	if match == nil {
+		return "", false
+	}
+	if index := r.Pattern.SubexpIndex("value"); index > 0 {
+		return match[index], match[index] != ""
+	}
+	if len(match) > 1 {
+		return match[1], match[1] != ""
+	}
+	return match[0], match[0] != ""
+}
+
+func (r *AlertSourceMappingRule) matches(source AlertSource, value string, service Service) bool {
+	switch r.Match {
+	case AlertSourceMatchAlias:
+		for _, alias := range service.Aliases {
+			if NormalizeAlias(alias) == NormalizeAlias(value) {
+				return true
+			}
+		}
+	case AlertSourceMatchName:
+		return strings.EqualFold(service.Name, value)
+	case AlertSourceMatchTag:
+		if service.Tags == nil {
+			return false
+		}
+		for _, tag := range service.Tags.Nodes {
+			if tag.Key == r.TagKey && (strings.EqualFold(tag.Value, value) || tag.Value == source.ExternalId) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type AlertSourceMapping struct {
+	AlertSource AlertSource
+	Service     ServiceId
+	Rule        int // Index of the rule that matched
+}
+
+type AlertSourceAmbiguity struct {
+	AlertSource AlertSource
+	Services    []ServiceId
+	Rule        int
+}
+
+type AlertSourceMappingResult struct {
+	Matched   []AlertSourceMapping
+	Ambiguous []AlertSourceAmbiguity
+	Unmatched []AlertSource
+	Create    []AlertSourceMapping // Matched alert sources not yet linked to their service
+	Delete    []AlertSourceService // Links of matched alert sources to other services
+	Errors    []error
+}
+
+// Err joins all the errors encountered while applying the result
+func (r *AlertSourceMappingResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	var messages []string
+	for _, err := range r.Errors {
+		messages = append(messages, err.Error())
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+// MatchAlertSources applies 'rules' in order to each alert source - the first rule matching any service decides
+// and the alert source is ambiguous if that rule matches more than one service
+func MatchAlertSources(sources []AlertSource, services []Service, rules []AlertSourceMappingRule) *AlertSourceMappingResult {
+	result := &AlertSourceMappingResult{}
+	for _, source := range sources {
+		matched := false
+		for index, rule := range rules {
+			value, ok := rule.value(source)
+			if !ok {
+				continue
+			}
+			var candidates []ServiceId
+			for _, service := range services {
+				if rule.matches(source, value, service) {
+					candidates = append(candidates, service.ServiceId)
+				}
+			}
+			if len(candidates) == 0 {
+				continue
+			}
+			matched = true
+			if len(candidates) > 1 {
+				result.Ambiguous = append(result.Ambiguous, AlertSourceAmbiguity{AlertSource: source, Services: candidates, Rule: index})
+			} else {
+				result.Matched = append(result.Matched, AlertSourceMapping{AlertSource: source, Service: candidates[0], Rule: index})
+			}
+			break
+		}
+		if !matched {
+			result.Unmatched = append(result.Unmatched, source)
+		}
+	}
+	return result
+}
+
+// Reconcile compares the matches against the 'existing' links filling in Create and, when 'prune' is set, Delete
+func (r *AlertSourceMappingResult) Reconcile(existing []AlertSourceService, prune bool) {
+	r.Create = nil
+	r.Delete = nil
+	for _, mapping := range r.Matched {
+		linked := false
+		for _, link := range existing {
+			if link.AlertSource.Id != mapping.AlertSource.Id {
+				continue
+			}
+			if link.Service.Id == mapping.Service.Id {
+				linked = true
+			} else if prune {
+				r.Delete = append(r.Delete, link)
+			}
+		}
+		if !linked {
+			r.Create = append(r.Create, mapping)
+		}
+	}
+}
+
+// AlertSourceMapper keeps the alert sources of the given types linked to the services the rules match them to
+type AlertSourceMapper struct {
+	Client *Client
+	Types  []AlertSourceTypeEnum // Alert source types to map - all when empty
+	Rules  []AlertSourceMappingRule
+	Prune  bool // Delete links of matched alert sources to other services
+	DryRun bool
+}
+
+func NewAlertSourceMapper(client *Client, types ...AlertSourceTypeEnum) *AlertSourceMapper {
+	return &AlertSourceMapper{
+		Client: client,
+		Types:  types,
+		Rules:  DefaultAlertSourceMappingRules(),
+	}
+}
+
+// Plan lists the alert sources and services, matches them and reconciles the matches with the existing links
+func (m *AlertSourceMapper) Plan() (*AlertSourceMappingResult, error) {
+	sources, err := m.Client.ListAlertSources(nil)
+	if err != nil {
+		return nil, err
+	}
+	services, err := m.Client.ListServices(nil)
+	if err != nil {
+		return nil, err
+	}
+	result := MatchAlertSources(sources.OfType(m.Types...), services.Nodes, m.Rules)
+
+	var ids []ID
+	for _, service := range services.Nodes {
+		if !m.Prune && !slices.ContainsFunc(result.Matched, func(mapping AlertSourceMapping) bool { return mapping.Service.Id == service.Id }) {
+			continue
+		}
+		ids = append(ids, service.Id)
+	}
+	existing, err := m.Client.ListAlertSourceServices(ids...)
+	if err != nil {
+		return nil, err
+	}
+	result.Reconcile(existing, m.Prune)
+	return result, nil
+}
+
+// Apply creates and deletes the links in the result, recording failures in its Errors
+func (m *AlertSourceMapper) Apply(result *AlertSourceMappingResult) error {
+	for _, link := range result.Delete {
+		if err := m.Client.DeleteAlertSourceService(link.Id); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("delete link of alert source '%s' to service '%s': %w", link.AlertSource.Name, link.Service.Id, err))
+		}
+	}
+	for _, mapping := range result.Create {
+		_, err := m.Client.CreateAlertSourceService(AlertSourceServiceCreateInput{
+			Service: *NewIdentifier(string(mapping.Service.Id)),
+			Id:      mapping.AlertSource.Id,
+		})
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("link alert source '%s' to service '%s': %w", mapping.AlertSource.Name, mapping.Service.Id, err))
+		}
+	}
+	return result.Err()
+}
+
+// Sync plans the mapping and, unless DryRun is set, applies it
+func (m *AlertSourceMapper) Sync() (*AlertSourceMappingResult, error) {
+	result, err := m.Plan()
+	if err != nil {
+		return nil, err
+	}
+	if m.DryRun {
+		return result, nil
+	}
+	return result, m.Apply(result)
+}
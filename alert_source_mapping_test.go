@@ -0,0 +1,101 @@
+package opslevel_test
+
+import (
+	"regexp"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+var (
+	testMappingAlertSources = []ol.AlertSource{
+		{Id: id1, Name: "Checkout", Type: ol.AlertSourceTypeEnumPagerduty, ExternalId: "PXXXX01"},
+		{Id: id2, Name: "payments-prod", Type: ol.AlertSourceTypeEnumOpsgenie, ExternalId: "XXXX-02"},
+		{Id: id3, Name: "Shared Infra", Type: ol.AlertSourceTypeEnumPagerduty, ExternalId: "PXXXX03"},
+		{Id: id4, Name: "Search", Type: ol.AlertSourceTypeEnumPagerduty, ExternalId: "PXXXX04"},
+	}
+	testMappingServices = []ol.Service{
+		{ServiceId: ol.ServiceId{Id: id1, Aliases: []string{"checkout", "checkout-api"}}, Name: "Checkout API"},
+		{ServiceId: ol.ServiceId{Id: id2, Aliases: []string{"payments"}}, Name: "Payments"},
+		{ServiceId: ol.ServiceId{Id: id3, Aliases: []string{"network"}}, Name: "Network", Tags: &ol.TagConnection{Nodes: []ol.Tag{{Key: "pagerduty", Value: "PXXXX03"}}}},
+		{ServiceId: ol.ServiceId{Id: id4, Aliases: []string{"dns"}}, Name: "DNS", Tags: &ol.TagConnection{Nodes: []ol.Tag{{Key: "pagerduty", Value: "PXXXX03"}}}},
+	}
+	testMappingRules = []ol.AlertSourceMappingRule{
+		{Match: ol.AlertSourceMatchAlias},
+		{Match: ol.AlertSourceMatchAlias, Pattern: regexp.MustCompile(`^(?P<value>.+)-prod$`)},
+		{Match: ol.AlertSourceMatchTag, TagKey: "pagerduty", Types: []ol.AlertSourceTypeEnum{ol.AlertSourceTypeEnumPagerduty}},
+	}
+)
+
+func TestMatchAlertSources(t *testing.T) {
+	// Act
+	result := ol.MatchAlertSources(testMappingAlertSources, testMappingServices, testMappingRules)
+	// Assert
+	autopilot.Equals(t, 2, len(result.Matched))
+	autopilot.Equals(t, id1, result.Matched[0].Service.Id)
+	autopilot.Equals(t, 0, result.Matched[0].Rule)
+	autopilot.Equals(t, id2, result.Matched[1].Service.Id)
+	autopilot.Equals(t, 1, result.Matched[1].Rule)
+	autopilot.Equals(t, 1, len(result.Ambiguous))
+	autopilot.Equals(t, id3, result.Ambiguous[0].AlertSource.Id)
+	autopilot.Equals(t, 2, len(result.Ambiguous[0].Services))
+	autopilot.Equals(t, []ol.AlertSource{testMappingAlertSources[3]}, result.Unmatched)
+}
+
+func TestMatchAlertSourcesNormalizesAliases(t *testing.T) {
+	// Arrange
+	sources := []ol.AlertSource{{Id: id1, Name: "Order Router", Type: ol.AlertSourceTypeEnumPagerduty}}
+	services := []ol.Service{{ServiceId: ol.ServiceId{Id: id2, Aliases: []string{"order_router"}}, Name: "Orders"}}
+	// Act
+	result := ol.MatchAlertSources(sources, services, []ol.AlertSourceMappingRule{{Match: ol.AlertSourceMatchAlias}})
+	// Assert
+	autopilot.Equals(t, 1, len(result.Matched))
+	autopilot.Equals(t, id2, result.Matched[0].Service.Id)
+}
+
+func TestAlertSourceMappingReconcile(t *testing.T) {
+	// Arrange
+	result := ol.MatchAlertSources(testMappingAlertSources, testMappingServices, testMappingRules)
+	existing := []ol.AlertSourceService{
+		{Id: "link-1", AlertSource: testMappingAlertSources[0], Service: ol.ServiceId{Id: id1}},
+		{Id: "link-2", AlertSource: testMappingAlertSources[1], Service: ol.ServiceId{Id: id3}},
+	}
+	// Act
+	result.Reconcile(existing, false)
+	withoutPrune := len(result.Delete)
+	result.Reconcile(existing, true)
+	// Assert
+	autopilot.Equals(t, 0, withoutPrune)
+	autopilot.Equals(t, 1, len(result.Create))
+	autopilot.Equals(t, id2, result.Create[0].AlertSource.Id)
+	autopilot.Equals(t, 1, len(result.Delete))
+	autopilot.Equals(t, ol.ID("link-2"), result.Delete[0].Id)
+}
+
+func TestAlertSourceMapperApply(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"mutation AlertSourceServiceDelete($input:AlertSourceDeleteInput!){alertSourceServiceDelete(input: $input){errors{message,path}}}"`,
+		`{"input": { "id": "link-2" }}`,
+		`{"data": { "alertSourceServiceDelete": { "errors": [] }}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"mutation AlertSourceServiceCreate($input:AlertSourceServiceCreateInput!){alertSourceServiceCreate(input: $input){alertSourceService{alertSource{name,description,id,type,externalId,integration{id,name,type},url},id,service{id,aliases},status},errors{message,path}}}"`,
+		`{"input": { "alertSourceId": "{{ template "id2_string" }}", "service": { {{ template "id2" }} }}}`,
+		`{"data": { "alertSourceServiceCreate": { "alertSourceService": { "service": { {{ template "id2" }}, "aliases": ["payments"] }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "alert_source/mapper_apply", testRequestOne, testRequestTwo)
+	mapper := ol.NewAlertSourceMapper(client, ol.AlertSourceTypeEnumPagerduty, ol.AlertSourceTypeEnumOpsgenie)
+	mapper.Rules = testMappingRules
+	result := ol.MatchAlertSources(testMappingAlertSources, testMappingServices, mapper.Rules)
+	result.Reconcile([]ol.AlertSourceService{
+		{Id: "link-1", AlertSource: testMappingAlertSources[0], Service: ol.ServiceId{Id: id1}},
+		{Id: "link-2", AlertSource: testMappingAlertSources[1], Service: ol.ServiceId{Id: id3}},
+	}, true)
+	// Act
+	err := mapper.Apply(result)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 0, len(result.Errors))
+}
@@ -107,3 +107,76 @@ func TestDeleteAlertSourceService(t *testing.T) {
 	// Assert
 	autopilot.Equals(t, nil, err)
 }
+
+func TestListAlertSources(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query AlertSourceList($after:String!$first:Int!){account{alertSources(after: $after, first: $first){nodes{name,description,id,type,externalId,integration{id,name,type},url},{{ template "pagination_request" }},totalCount}}}"`,
+		`{ {{ template "first_page_variables" }} }`,
+		`{"data": { "account": { "alertSources": { "nodes": [
+          { {{ template "id1" }}, "name": "checkout", "type": "pagerduty", "externalId": "PXXXX01" },
+          { {{ template "id2" }}, "name": "payments-prod", "type": "opsgenie", "externalId": "XXXX-02" },
+          { {{ template "id3" }}, "name": "CPU high", "type": "datadog", "externalId": "12345678" }
+        ], {{ template "no_pagination_response" }}, "totalCount": 3 }}}}`,
+	)
+	client := BestTestClient(t, "alert_source/list", testRequest)
+	// Act
+	result, err := client.ListAlertSources(nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 3, result.TotalCount)
+	autopilot.Equals(t, 2, len(result.OfType(ol.AlertSourceTypeEnumPagerduty, ol.AlertSourceTypeEnumOpsgenie)))
+	autopilot.Equals(t, 3, len(result.OfType()))
+}
+
+func TestGetServiceAlertSources(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query ServiceAlertSourcesList($after:String!$first:Int!$service:ID!){account{service(id: $service){alertSources(after: $after, first: $first){nodes{alertSource{name,description,id,type,externalId,integration{id,name,type},url},id,service{id,aliases},status},{{ template "pagination_request" }},totalCount}}}}"`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id1_string" }}" }`,
+		`{"data": { "account": { "service": { "alertSources": { "nodes": [
+          { "alertSource": { {{ template "id2" }}, "name": "checkout", "type": "pagerduty" }, {{ template "id3" }}, "service": { {{ template "id1" }}, "aliases": ["checkout"] }, "status": "ok" }
+        ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}}`,
+	)
+	client := BestTestClient(t, "alert_source/service_list", testRequest)
+	service := ol.Service{ServiceId: ol.ServiceId{Id: id1}}
+	// Act
+	result, err := service.GetAlertSources(client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, result.TotalCount)
+	autopilot.Equals(t, id2, result.Nodes[0].AlertSource.Id)
+	autopilot.Equals(t, id3, result.Nodes[0].Id)
+}
+
+func TestListAlertSourceServices(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"query ServicesAlertSourcesList($first:Int!$s0:ID!$s1:ID!){account{s0: service(id: $s0){alertSources(first: $first){nodes{alertSource{name,description,id,type,externalId,integration{id,name,type},url},id,service{id,aliases},status},{{ template "pagination_request" }},totalCount}},s1: service(id: $s1){alertSources(first: $first){nodes{alertSource{name,description,id,type,externalId,integration{id,name,type},url},id,service{id,aliases},status},{{ template "pagination_request" }},totalCount}}}}"`,
+		`{ "first": 100, "s0": "{{ template "id1_string" }}", "s1": "{{ template "id2_string" }}" }`,
+		`{"data": { "account": {
+          "s0": { "alertSources": { "nodes": [
+            { "alertSource": { {{ template "id3" }}, "name": "checkout", "type": "pagerduty" }, "id": "{{ template "id1_string" }}", "service": { {{ template "id1" }} }, "status": "ok" }
+          ], {{ template "no_pagination_response" }}, "totalCount": 1 }},
+          "s1": { "alertSources": { "nodes": [
+            { "alertSource": { {{ template "id3" }}, "name": "checkout", "type": "pagerduty" }, "id": "{{ template "id2_string" }}", "service": { {{ template "id2" }} }, "status": "ok" }
+          ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1 }}
+        }}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"query ServiceAlertSourcesList($after:String!$first:Int!$service:ID!){account{service(id: $service){alertSources(after: $after, first: $first){nodes{alertSource{name,description,id,type,externalId,integration{id,name,type},url},id,service{id,aliases},status},{{ template "pagination_request" }},totalCount}}}}"`,
+		`{ {{ template "second_page_variables" }}, "service": "{{ template "id2_string" }}" }`,
+		`{"data": { "account": { "service": { "alertSources": { "nodes": [
+          { "alertSource": { {{ template "id4" }}, "name": "payments", "type": "opsgenie" }, "id": "{{ template "id3_string" }}", "service": { {{ template "id2" }} }, "status": "ok" }
+        ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}}`,
+	)
+	client := BestTestClient(t, "alert_source/services_list", testRequestOne, testRequestTwo)
+	// Act
+	result, err := client.ListAlertSourceServices(id1, id2)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 3, len(result))
+	autopilot.Equals(t, id1, result[0].Service.Id)
+	autopilot.Equals(t, id2, result[1].Service.Id)
+	autopilot.Equals(t, id4, result[2].AlertSource.Id)
+}
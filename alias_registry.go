@@ -0,0 +1,355 @@
+package opslevel
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gosimple/slug"
+)
+
+// AliasOwnerKind is the kind of entity an AliasOwner is - the AliasOwnerTypeEnum values
+// and the kinds of entities with aliases the API has no owner type for
+type AliasOwnerKind string
+
+const (
+	AliasOwnerKindService                = AliasOwnerKind(AliasOwnerTypeEnumService)
+	AliasOwnerKindTeam                   = AliasOwnerKind(AliasOwnerTypeEnumTeam)
+	AliasOwnerKindSystem                 = AliasOwnerKind(AliasOwnerTypeEnumSystem)
+	AliasOwnerKindDomain                 = AliasOwnerKind(AliasOwnerTypeEnumDomain)
+	AliasOwnerKindInfrastructureResource = AliasOwnerKind(AliasOwnerTypeEnumInfrastructureResource)
+	AliasOwnerKindScorecard              = AliasOwnerKind(AliasOwnerTypeEnumScorecard)
+	AliasOwnerKindCustomAction           = AliasOwnerKind("custom_action") // Custom actions and trigger definitions
+)
+
+// OwnerType returns the AliasOwnerTypeEnum of the kind, false when the API has no owner type for it
+func (k AliasOwnerKind) OwnerType() (AliasOwnerTypeEnum, bool) {
+	if slices.Contains(AllAliasOwnerTypeEnum, string(k)) {
+		return AliasOwnerTypeEnum(k), true
+	}
+	return "", false
+}
+
+// AliasOwner is an entity that owns aliases
+type AliasOwner struct {
+	Id   ID
+	Type AliasOwnerKind
+	Name string
+}
+
+func (o AliasOwner) String() string {
+	return fmt.Sprintf("%s '%s' (%s)", o.Type, o.Name, o.Id)
+}
+
+// AliasConflict is a set of owners whose aliases are equal, or for near duplicates only equal once normalized
+type AliasConflict struct {
+	Key     string // Normalized alias
+	Aliases map[string][]AliasOwner
+}
+
+// NearDuplicate is true when the owners use different spellings of the same normalized alias, e.g. 'my-api' and 'my_api'
+func (c AliasConflict) NearDuplicate() bool {
+	return len(c.Aliases) > 1
+}
+
+type AliasMove struct {
+	Alias string
+	From  AliasOwner
+	To    ID // An entity of the same type as From
+}
+
+// AliasRegistry indexes the aliases of entities to find collisions before they are created
+type AliasRegistry struct {
+	owners map[string][]AliasOwner // keyed by alias
+}
+
+func NewAliasRegistry() *AliasRegistry {
+	return &AliasRegistry{owners: map[string][]AliasOwner{}}
+}
+
+var (
+	nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+	aliasSeparators = regexp.MustCompile(`[-_]+`)
+)
+
+// NormalizeAlias reduces an alias to the form two aliases collide in, ignoring case and which separators are used,
+// e.g. 'My API', 'my-api' and 'my_api' collide but 'myapi' does not
+func NormalizeAlias(alias string) string {
+	return strings.Trim(aliasSeparators.ReplaceAllString(slug.Make(alias), "-"), "-")
+}
+
+// PredictAlias returns the alias OpsLevel generates from the name of a new entity
+func PredictAlias(name string) string {
+	return strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
+
+func (r *AliasRegistry) Add(owner AliasOwner, aliases ...string) {
+	for _, alias := range aliases {
+		if alias == "" {
+			continue
+		}
+		found := false
+		for _, existing := range r.owners[alias] {
+			if existing.Id == owner.Id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r.owners[alias] = append(r.owners[alias], owner)
+		}
+	}
+}
+
+func (r *AliasRegistry) Remove(ownerId ID, alias string) {
+	owners := r.owners[alias][:0]
+	for _, owner := range r.owners[alias] {
+		if owner.Id != ownerId {
+			owners = append(owners, owner)
+		}
+	}
+	if len(owners) == 0 {
+		delete(r.owners, alias)
+	} else {
+		r.owners[alias] = owners
+	}
+}
+
+// owner returns the registered owner with 'id', or an owner of 'ownerType' with only the ID when it has no aliases yet
+func (r *AliasRegistry) owner(id ID, ownerType AliasOwnerKind) AliasOwner {
+	for _, owners := range r.owners {
+		for _, owner := range owners {
+			if owner.Id == id {
+				return owner
+			}
+		}
+	}
+	return AliasOwner{Id: id, Type: ownerType}
+}
+
+// Owners returns the owners of exactly 'alias'
+func (r *AliasRegistry) Owners(alias string) []AliasOwner {
+	return r.owners[alias]
+}
+
+// Similar returns the aliases that normalize to the same value as 'alias', including 'alias' itself if registered
+func (r *AliasRegistry) Similar(alias string) map[string][]AliasOwner {
+	key := NormalizeAlias(alias)
+	output := map[string][]AliasOwner{}
+	for existing, owners := range r.owners {
+		if NormalizeAlias(existing) == key {
+			output[existing] = owners
+		}
+	}
+	return output
+}
+
+// Conflicts returns every alias owned by more than one entity and every set of near duplicate aliases, sorted by key
+func (r *AliasRegistry) Conflicts() []AliasConflict {
+	byKey := map[string]map[string][]AliasOwner{}
+	for alias, owners := range r.owners {
+		key := NormalizeAlias(alias)
+		if byKey[key] == nil {
+			byKey[key] = map[string][]AliasOwner{}
+		}
+		byKey[key][alias] = owners
+	}
+	var output []AliasConflict
+	for key, aliases := range byKey {
+		owners := map[ID]bool{}
+		for _, items := range aliases {
+			for _, owner := range items {
+				owners[owner.Id] = true
+			}
+		}
+		if len(owners) > 1 {
+			output = append(output, AliasConflict{Key: key, Aliases: aliases})
+		}
+	}
+	sort.Slice(output, func(i, j int) bool { return output[i].Key < output[j].Key })
+	return output
+}
+
+// CheckAvailable returns an error describing the entities that already use 'alias' or a near duplicate of it
+func (r *AliasRegistry) CheckAvailable(alias string) error {
+	similar := r.Similar(alias)
+	if len(similar) == 0 {
+		return nil
+	}
+	aliases := make([]string, 0, len(similar))
+	for existing := range similar {
+		aliases = append(aliases, existing)
+	}
+	sort.Strings(aliases)
+	var messages []string
+	for _, existing := range aliases {
+		for _, owner := range similar[existing] {
+			if existing == alias {
+				messages = append(messages, fmt.Sprintf("alias '%s' is used by %s", alias, owner))
+			} else {
+				messages = append(messages, fmt.Sprintf("alias '%s' is a near duplicate of '%s' used by %s", alias, existing, owner))
+			}
+		}
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+// PredictAlias returns the alias OpsLevel generates for a new entity named 'name' and whether it is already in use
+func (r *AliasRegistry) PredictAlias(name string) (string, bool) {
+	alias := PredictAlias(name)
+	return alias, len(r.Similar(alias)) > 0
+}
+
+// LoadAliasRegistry lists the entities of each type, all of them when no types are given, and registers their aliases
+func (client *Client) LoadAliasRegistry(types ...AliasOwnerKind) (*AliasRegistry, error) {
+	if len(types) == 0 {
+		types = []AliasOwnerKind{
+			AliasOwnerKindService, AliasOwnerKindTeam, AliasOwnerKindInfrastructureResource,
+			AliasOwnerKindScorecard, AliasOwnerKindDomain, AliasOwnerKindSystem, AliasOwnerKindCustomAction,
+		}
+	}
+	registry := NewAliasRegistry()
+	for _, ownerType := range types {
+		if err := client.loadAliases(registry, ownerType); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+func (client *Client) loadAliases(registry *AliasRegistry, ownerType AliasOwnerKind) error {
+	switch ownerType {
+	case AliasOwnerKindService:
+		resp, err := client.ListServices(nil)
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Nodes {
+			registry.Add(AliasOwner{Id: item.Id, Type: ownerType, Name: item.Name}, item.Aliases...)
+		}
+	case AliasOwnerKindTeam:
+		resp, err := client.ListTeams(nil)
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Nodes {
+			registry.Add(AliasOwner{Id: item.Id, Type: ownerType, Name: item.Name}, append([]string{item.Alias}, item.Aliases...)...)
+		}
+	case AliasOwnerKindInfrastructureResource:
+		resp, err := client.ListInfrastructure(nil)
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Nodes {
+			registry.Add(AliasOwner{Id: ID(item.Id), Type: ownerType, Name: item.Name}, item.Aliases...)
+		}
+	case AliasOwnerKindScorecard:
+		resp, err := client.ListScorecards(nil)
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Nodes {
+			registry.Add(AliasOwner{Id: item.Id, Type: ownerType, Name: item.Name}, item.Aliases...)
+		}
+	case AliasOwnerKindDomain:
+		resp, err := client.ListDomains(nil)
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Nodes {
+			registry.Add(AliasOwner{Id: item.Id, Type: ownerType, Name: item.Name}, item.Aliases...)
+		}
+	case AliasOwnerKindSystem:
+		resp, err := client.ListSystems(nil)
+		if err != nil {
+			return err
+		}
+		for _, item := range resp.Nodes {
+			registry.Add(AliasOwner{Id: item.Id, Type: ownerType, Name: item.Name}, item.Aliases...)
+		}
+	case AliasOwnerKindCustomAction:
+		actions, err := client.ListCustomActions(nil)
+		if err != nil {
+			return err
+		}
+		for _, item := range actions.Nodes {
+			registry.Add(AliasOwner{Id: item.Id, Type: ownerType, Name: item.Name}, item.Aliases...)
+		}
+		triggers, err := client.ListTriggerDefinitions(nil)
+		if err != nil {
+			return err
+		}
+		for _, item := range triggers.Nodes {
+			registry.Add(AliasOwner{Id: item.Id, Type: ownerType, Name: item.Name}, item.Aliases...)
+		}
+	default:
+		return fmt.Errorf("unsupported alias owner type '%s'", ownerType)
+	}
+	return nil
+}
+
+// CreateAliasesChecked adds the aliases to each owner after checking none of them are in use, registering them on success
+func (client *Client) CreateAliasesChecked(registry *AliasRegistry, aliases map[AliasOwner][]string) error {
+	var messages []string
+	for _, items := range aliases {
+		for _, alias := range items {
+			if err := registry.CheckAvailable(alias); err != nil {
+				messages = append(messages, err.Error())
+			}
+		}
+	}
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, "\n"))
+	}
+	owners := make([]AliasOwner, 0, len(aliases))
+	for owner := range aliases {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool { return owners[i].Id < owners[j].Id })
+	for _, owner := range owners {
+		created, err := client.CreateAliases(owner.Id, aliases[owner])
+		registry.Add(owner, created...)
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, "\n"))
+	}
+	return nil
+}
+
+// MoveAliases deletes each alias from its current owner and adds it to the new one, updating the registry.
+// When the alias cannot be added to the new owner it is added back to its current owner.
+func (client *Client) MoveAliases(registry *AliasRegistry, moves ...AliasMove) error {
+	var messages []string
+	for _, move := range moves {
+		ownerType, ok := move.From.Type.OwnerType()
+		if !ok {
+			messages = append(messages, fmt.Sprintf("alias '%s': aliases of %s cannot be deleted", move.Alias, move.From))
+			continue
+		}
+		if err := client.DeleteAlias(AliasDeleteInput{Alias: move.Alias, OwnerType: ownerType}); err != nil {
+			messages = append(messages, fmt.Sprintf("alias '%s': %s", move.Alias, err))
+			continue
+		}
+		registry.Remove(move.From.Id, move.Alias)
+		if _, err := client.CreateAlias(AliasCreateInput{Alias: move.Alias, OwnerId: move.To}); err != nil {
+			if _, restoreErr := client.CreateAlias(AliasCreateInput{Alias: move.Alias, OwnerId: move.From.Id}); restoreErr != nil {
+				messages = append(messages, fmt.Sprintf("alias '%s': %s\nalias '%s' could not be restored to %s: %s", move.Alias, err, move.Alias, move.From, restoreErr))
+				continue
+			}
+			registry.Add(move.From, move.Alias)
+			messages = append(messages, fmt.Sprintf("alias '%s': %s - it was restored to %s", move.Alias, err, move.From))
+			continue
+		}
+		registry.Add(registry.owner(move.To, move.From.Type), move.Alias)
+	}
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package opslevel_test
+
+import (
+	"strings"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func newTestAliasRegistry() *ol.AliasRegistry {
+	registry := ol.NewAliasRegistry()
+	registry.Add(ol.AliasOwner{Id: id1, Type: ol.AliasOwnerKindService, Name: "Checkout API"}, "checkout_api", "checkout")
+	registry.Add(ol.AliasOwner{Id: id2, Type: ol.AliasOwnerKindTeam, Name: "Checkout"}, "checkout")
+	registry.Add(ol.AliasOwner{Id: id3, Type: ol.AliasOwnerKindSystem, Name: "Checkout API"}, "checkout-api")
+	registry.Add(ol.AliasOwner{Id: id4, Type: ol.AliasOwnerKindDomain, Name: "Payments"}, "payments")
+	return registry
+}
+
+func TestPredictAlias(t *testing.T) {
+	autopilot.Equals(t, "checkout_api", ol.PredictAlias("Checkout API"))
+	autopilot.Equals(t, "my_service_v2", ol.PredictAlias(" My Service (v2) "))
+	autopilot.Equals(t, "my-service-v2", ol.NormalizeAlias("My-Service_v2"))
+	autopilot.Equals(t, ol.NormalizeAlias("my_api"), ol.NormalizeAlias("My API"))
+	autopilot.Assert(t, ol.NormalizeAlias("myapi") != ol.NormalizeAlias("my-api"), "aliases that only differ by a separator being present must not collide")
+}
+
+func TestAliasRegistryConflicts(t *testing.T) {
+	// Arrange
+	registry := newTestAliasRegistry()
+	// Act
+	result := registry.Conflicts()
+	// Assert
+	autopilot.Equals(t, 2, len(result))
+	autopilot.Equals(t, "checkout", result[0].Key)
+	autopilot.Equals(t, false, result[0].NearDuplicate())
+	autopilot.Equals(t, 2, len(result[0].Aliases["checkout"]))
+	autopilot.Equals(t, "checkout-api", result[1].Key)
+	autopilot.Equals(t, true, result[1].NearDuplicate())
+}
+
+func TestAliasRegistryCheckAvailable(t *testing.T) {
+	// Arrange
+	registry := newTestAliasRegistry()
+	// Act
+	alias, taken := registry.PredictAlias("Payments")
+	err := registry.CheckAvailable("Payments")
+	// Assert
+	autopilot.Equals(t, "payments", alias)
+	autopilot.Equals(t, true, taken)
+	autopilot.Equals(t, "alias 'Payments' is a near duplicate of 'payments' used by domain 'Payments' ("+string(id4)+")", err.Error())
+	autopilot.Ok(t, registry.CheckAvailable("orders"))
+}
+
+func TestCreateAliasesCheckedConflict(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "aliases/create_checked_conflict")
+	registry := newTestAliasRegistry()
+	// Act
+	err := client.CreateAliasesChecked(registry, map[ol.AliasOwner][]string{{Id: id4, Type: ol.AliasOwnerKindDomain, Name: "Payments"}: {"checkout"}})
+	// Assert
+	autopilot.Equals(t, "alias 'checkout' is used by service 'Checkout API' ("+string(id1)+")\nalias 'checkout' is used by team 'Checkout' ("+string(id2)+")", err.Error())
+}
+
+func TestCreateAliasesChecked(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation AliasCreate($input:AliasCreateInput!){aliasCreate(input: $input){aliases,ownerId,errors{message,path}}}"`,
+		`{"input": { "alias": "orders", "ownerId": "{{ template "id4_string" }}" }}`,
+		`{"data": { "aliasCreate": { "aliases": [ "payments", "orders" ], "ownerId": "{{ template "id4_string" }}", "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "aliases/create_checked", testRequest)
+	registry := newTestAliasRegistry()
+	owner := ol.AliasOwner{Id: id4, Type: ol.AliasOwnerKindDomain, Name: "Payments"}
+	// Act
+	err := client.CreateAliasesChecked(registry, map[ol.AliasOwner][]string{owner: {"orders"}})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.AliasOwner{owner}, registry.Owners("orders"))
+}
+
+func TestMoveAliases(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"mutation AliasDelete($input:AliasDeleteInput!){aliasDelete(input: $input){deletedAlias,errors{message,path}}}"`,
+		`{"input": { "alias": "checkout", "ownerType": "team" }}`,
+		`{"data": { "aliasDelete": { "deletedAlias": "checkout", "errors": [] }}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"mutation AliasCreate($input:AliasCreateInput!){aliasCreate(input: $input){aliases,ownerId,errors{message,path}}}"`,
+		`{"input": { "alias": "checkout", "ownerId": "{{ template "id4_string" }}" }}`,
+		`{"data": { "aliasCreate": { "aliases": [ "payments", "checkout" ], "ownerId": "{{ template "id4_string" }}", "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "aliases/move", testRequestOne, testRequestTwo)
+	registry := newTestAliasRegistry()
+	// Act
+	err := client.MoveAliases(registry, ol.AliasMove{
+		Alias: "checkout",
+		From:  ol.AliasOwner{Id: id2, Type: ol.AliasOwnerKindTeam, Name: "Checkout"},
+		To:    id4,
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.ID{id1, id4}, []ol.ID{registry.Owners("checkout")[0].Id, registry.Owners("checkout")[1].Id})
+	autopilot.Equals(t, "Payments", registry.Owners("checkout")[1].Name)
+}
+
+func TestMoveAliasesRestoresOnFailure(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"mutation AliasDelete($input:AliasDeleteInput!){aliasDelete(input: $input){deletedAlias,errors{message,path}}}"`,
+		`{"input": { "alias": "checkout", "ownerType": "team" }}`,
+		`{"data": { "aliasDelete": { "deletedAlias": "checkout", "errors": [] }}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"mutation AliasCreate($input:AliasCreateInput!){aliasCreate(input: $input){aliases,ownerId,errors{message,path}}}"`,
+		`{"input": { "alias": "checkout", "ownerId": "{{ template "id4_string" }}" }}`,
+		`{"data": { "aliasCreate": { "aliases": [], "ownerId": "", "errors": [{ "message": "Domain not found", "path": ["ownerId"] }] }}}`,
+	)
+	testRequestThree := NewTestRequest(
+		`"mutation AliasCreate($input:AliasCreateInput!){aliasCreate(input: $input){aliases,ownerId,errors{message,path}}}"`,
+		`{"input": { "alias": "checkout", "ownerId": "{{ template "id2_string" }}" }}`,
+		`{"data": { "aliasCreate": { "aliases": [ "checkout" ], "ownerId": "{{ template "id2_string" }}", "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "aliases/move_restore", testRequestOne, testRequestTwo, testRequestThree)
+	registry := newTestAliasRegistry()
+	from := ol.AliasOwner{Id: id2, Type: ol.AliasOwnerKindTeam, Name: "Checkout"}
+	// Act
+	err := client.MoveAliases(registry, ol.AliasMove{Alias: "checkout", From: from, To: id4})
+	// Assert
+	autopilot.Assert(t, err != nil, "This test should throw an error.")
+	autopilot.Assert(t, strings.Contains(err.Error(), "Domain not found"), err.Error())
+	autopilot.Assert(t, strings.Contains(err.Error(), "restored to team 'Checkout'"), err.Error())
+	autopilot.Equals(t, []ol.ID{id1, id2}, []ol.ID{registry.Owners("checkout")[0].Id, registry.Owners("checkout")[1].Id})
+}
+
+func TestMoveAliasesCustomAction(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "aliases/move_custom_action")
+	registry := newTestAliasRegistry()
+	from := ol.AliasOwner{Id: id3, Type: ol.AliasOwnerKindCustomAction, Name: "Rollback"}
+	registry.Add(from, "rollback")
+	// Act
+	err := client.MoveAliases(registry, ol.AliasMove{Alias: "rollback", From: from, To: id4})
+	// Assert
+	autopilot.Equals(t, "alias 'rollback': aliases of custom_action 'Rollback' ("+string(id3)+") cannot be deleted", err.Error())
+	autopilot.Equals(t, []ol.AliasOwner{from}, registry.Owners("rollback"))
+}
@@ -0,0 +1,197 @@
+package opslevel
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BatchDefaultChunkSize is the number of mutations a Batch sends per request unless ChunkSize is set
+const BatchDefaultChunkSize = 50
+
+// BatchOperation is a single mutation of a Batch
+type BatchOperation struct {
+	Mutation string // e.g. 'tagCreate'
+	Argument string // The name of the mutation's input argument, usually 'input'
+	Input    any
+	// Payload is a pointer to the struct the mutation payload is decoded into, it must have an
+	// 'Errors []OpsLevelErrors' field
+	Payload any
+	err     error
+}
+
+// BatchResult is the outcome of the BatchOperation at the same index
+type BatchResult struct {
+	Mutation string
+	Input    any
+	Err      error
+}
+
+// Batch collects mutations and sends them as aliased fields of as few GraphQL documents as ChunkSize allows
+//
+//	batch := client.NewBatch()
+//	tag := batch.CreateTag(TagCreateInput{Id: id, Key: "env", Value: "prod"})
+//	tool := batch.CreateTool(ToolCreateInput{...})
+//	results, err := batch.Execute()
+type Batch struct {
+	ChunkSize  int
+	client     *Client
+	operations []BatchOperation
+}
+
+func (client *Client) NewBatch() *Batch {
+	return &Batch{
+		ChunkSize: BatchDefaultChunkSize,
+		client:    client,
+	}
+}
+
+// Len returns the number of mutations waiting to be executed
+func (b *Batch) Len() int {
+	return len(b.operations)
+}
+
+// Add queues a mutation returning its index in the results of Execute
+func (b *Batch) Add(operation BatchOperation) int {
+	if operation.Argument == "" {
+		operation.Argument = "input"
+	}
+	if operation.err == nil {
+		if value := reflect.ValueOf(operation.Payload); value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+			operation.err = fmt.Errorf("payload of '%s' must be a pointer to a struct", operation.Mutation)
+		} else if field, ok := value.Elem().Type().FieldByName("Errors"); !ok {
+			operation.err = fmt.Errorf("payload of '%s' has no Errors field", operation.Mutation)
+		} else if field.Type != reflect.TypeOf([]OpsLevelErrors{}) {
+			operation.err = fmt.Errorf("Errors field of the payload of '%s' must be []OpsLevelErrors, not %s", operation.Mutation, field.Type)
+		}
+	}
+	b.operations = append(b.operations, operation)
+	return len(b.operations) - 1
+}
+
+// CreateTag queues a tagCreate mutation - the returned Tag is filled in by Execute
+func (b *Batch) CreateTag(input TagCreateInput) *Tag {
+	payload := &struct {
+		Tag    Tag
+		Errors []OpsLevelErrors
+	}{}
+	b.Add(BatchOperation{Mutation: "tagCreate", Input: input, Payload: payload, err: ValidateTagKey(input.Key)})
+	return &payload.Tag
+}
+
+// AssignTag queues a tagAssign mutation - the returned Tags are filled in by Execute
+func (b *Batch) AssignTag(input TagAssignInput) *[]Tag {
+	var err error
+	for _, tag := range input.Tags {
+		if err = ValidateTagKey(tag.Key); err != nil {
+			break
+		}
+	}
+	payload := &struct {
+		Tags   []Tag
+		Errors []OpsLevelErrors
+	}{}
+	b.Add(BatchOperation{Mutation: "tagAssign", Input: input, Payload: payload, err: err})
+	return &payload.Tags
+}
+
+// CreateTool queues a toolCreate mutation - the returned Tool is filled in by Execute
+func (b *Batch) CreateTool(input ToolCreateInput) *Tool {
+	payload := &struct {
+		Tool   Tool
+		Errors []OpsLevelErrors
+	}{}
+	b.Add(BatchOperation{Mutation: "toolCreate", Input: input, Payload: payload})
+	return &payload.Tool
+}
+
+// CreateAlias queues an aliasCreate mutation - the returned aliases of the owner are filled in by Execute
+func (b *Batch) CreateAlias(input AliasCreateInput) *[]string {
+	payload := &struct {
+		Aliases []string
+		OwnerId string
+		Errors  []OpsLevelErrors
+	}{}
+	b.Add(BatchOperation{Mutation: "aliasCreate", Input: input, Payload: payload})
+	return &payload.Aliases
+}
+
+// CreateServiceDependency queues a serviceDependencyCreate mutation - the returned ServiceDependency is filled in by Execute
+func (b *Batch) CreateServiceDependency(input ServiceDependencyCreateInput) *ServiceDependency {
+	payload := &struct {
+		ServiceDependency ServiceDependency
+		Errors            []OpsLevelErrors
+	}{}
+	b.Add(BatchOperation{Mutation: "serviceDependencyCreate", Argument: "inputV2", Input: input, Payload: payload})
+	return &payload.ServiceDependency
+}
+
+// Execute sends the queued mutations in chunks and empties the batch. A result is returned for every
+// mutation, in the order they were added, and the error joins the errors of all the failed ones.
+func (b *Batch) Execute() ([]BatchResult, error) {
+	operations := b.operations
+	b.operations = nil
+
+	results := make([]BatchResult, len(operations))
+	var pending []int
+	for i, operation := range operations {
+		results[i] = BatchResult{Mutation: operation.Mutation, Input: operation.Input, Err: operation.err}
+		if operation.err == nil {
+			pending = append(pending, i)
+		}
+	}
+
+	size := b.ChunkSize
+	if size <= 0 {
+		size = BatchDefaultChunkSize
+	}
+	for start := 0; start < len(pending); start += size {
+		end := min(start+size, len(pending))
+		b.execute(operations, pending[start:end], results)
+	}
+
+	var messages []string
+	for i, result := range results {
+		if result.Err != nil {
+			messages = append(messages, fmt.Sprintf("%s #%d: %s", result.Mutation, i, result.Err))
+		}
+	}
+	if len(messages) > 0 {
+		return results, errors.New(strings.Join(messages, "\n"))
+	}
+	return results, nil
+}
+
+// execute sends one document with a field aliased 'm<index>' per operation, e.g. 'm0: tagCreate(input: $input0)', and
+// records the outcome of each operation in 'results'. go-graphql-client drops the path of request errors, so an error is
+// attributed to the operations whose field came back null while the others keep the errors of their own payload.
+func (b *Batch) execute(operations []BatchOperation, chunk []int, results []BatchResult) {
+	fields := make([]reflect.StructField, len(chunk))
+	v := PayloadVariables{}
+	for n, i := range chunk {
+		operation := operations[i]
+		fields[n] = reflect.StructField{
+			Name: fmt.Sprintf("M%d", i),
+			Type: reflect.PointerTo(reflect.TypeOf(operation.Payload).Elem()),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"m%d: %s(%s: $input%d)"`, i, operation.Mutation, operation.Argument, i)),
+		}
+		v[fmt.Sprintf("input%d", i)] = operation.Input
+	}
+	m := reflect.New(reflect.StructOf(fields))
+	err := b.client.Mutate(m.Interface(), v, WithName("Batch"))
+	for n, i := range chunk {
+		field := m.Elem().Field(n)
+		if field.IsNil() {
+			if err != nil {
+				results[i].Err = err
+			} else {
+				results[i].Err = fmt.Errorf("no payload returned for '%s'", operations[i].Mutation)
+			}
+			continue
+		}
+		payload := reflect.ValueOf(operations[i].Payload).Elem()
+		payload.Set(field.Elem())
+		results[i].Err = FormatErrors(payload.FieldByName("Errors").Interface().([]OpsLevelErrors))
+	}
+}
@@ -0,0 +1,93 @@
+package opslevel_test
+
+import (
+	"strings"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestBatchExecute(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"mutation Batch($input0:TagCreateInput!$input2:ToolCreateInput!){m0: tagCreate(input: $input0){tag{id,key,value},errors{message,path}},m2: toolCreate(input: $input2){tool{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},errors{message,path}}}"`,
+		`{"input0": { {{ template "id1" }}, "key": "env", "value": "prod" }, "input2": { "category": "logs", "displayName": "Logs", "serviceId": "{{ template "id1_string" }}", "url": "https://logs.example.com" }}`,
+		`{"data": { "m0": { "tag": { {{ template "id2" }}, "key": "env", "value": "prod" }, "errors": [] }, "m2": { "tool": { {{ template "id3" }}, "displayName": "Logs" }, "errors": [] }}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"mutation Batch($input3:AliasCreateInput!){m3: aliasCreate(input: $input3){aliases,ownerId,errors{message,path}}}"`,
+		`{"input3": { "alias": "taken", "ownerId": "{{ template "id1_string" }}" }}`,
+		`{"data": { "m3": { "aliases": [], "ownerId": "", "errors": [{ "message": "alias 'taken' is in use", "path": ["alias"] }] }}}`,
+	)
+	client := BestTestClient(t, "batch/execute", testRequestOne, testRequestTwo)
+	batch := client.NewBatch()
+	batch.ChunkSize = 2
+	// Act
+	tag := batch.CreateTag(ol.TagCreateInput{Id: id1, Key: "env", Value: "prod"})
+	invalid := batch.CreateTag(ol.TagCreateInput{Id: id1, Key: "not valid!", Value: "prod"})
+	tool := batch.CreateTool(ol.ToolCreateInput{
+		Category:    ol.ToolCategoryLogs,
+		DisplayName: "Logs",
+		ServiceId:   id1,
+		Url:         "https://logs.example.com",
+	})
+	aliases := batch.CreateAlias(ol.AliasCreateInput{Alias: "taken", OwnerId: id1})
+	results, err := batch.Execute()
+	// Assert
+	autopilot.Assert(t, err != nil, "expected the failed mutations to be reported")
+	autopilot.Equals(t, 4, len(results))
+	autopilot.Equals(t, 0, batch.Len())
+	autopilot.Ok(t, results[0].Err)
+	autopilot.Assert(t, results[1].Err != nil, "expected the invalid tag key to fail without being sent")
+	autopilot.Ok(t, results[2].Err)
+	autopilot.Assert(t, results[3].Err != nil, "expected the alias error to map to its input")
+	autopilot.Equals(t, "taken", results[3].Input.(ol.AliasCreateInput).Alias)
+	autopilot.Equals(t, id2, tag.Id)
+	autopilot.Equals(t, "", invalid.Key)
+	autopilot.Equals(t, id3, tool.Id)
+	autopilot.Equals(t, 0, len(*aliases))
+}
+
+func TestBatchExecutePartialFailure(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation Batch($input0:TagCreateInput!$input1:TagCreateInput!){m0: tagCreate(input: $input0){tag{id,key,value},errors{message,path}},m1: tagCreate(input: $input1){tag{id,key,value},errors{message,path}}}"`,
+		`{"input0": { {{ template "id1" }}, "key": "env", "value": "prod" }, "input1": { {{ template "id4" }}, "key": "env", "value": "prod" }}`,
+		`{"data": { "m0": { "tag": { {{ template "id2" }}, "key": "env", "value": "prod" }, "errors": [] }, "m1": null },
+		  "errors": [{ "message": "resource not found", "path": ["m1"] }]}`,
+	)
+	client := BestTestClient(t, "batch/execute_partial_failure", testRequest)
+	batch := client.NewBatch()
+	// Act
+	tag := batch.CreateTag(ol.TagCreateInput{Id: id1, Key: "env", Value: "prod"})
+	batch.CreateTag(ol.TagCreateInput{Id: id4, Key: "env", Value: "prod"})
+	results, err := batch.Execute()
+	// Assert
+	autopilot.Assert(t, err != nil, "expected the failed mutation to be reported")
+	autopilot.Ok(t, results[0].Err)
+	autopilot.Equals(t, id2, tag.Id)
+	autopilot.Assert(t, results[1].Err != nil && strings.Contains(results[1].Err.Error(), "resource not found"), "expected the request error to be attributed to the null field")
+}
+
+func TestBatchExecuteEmpty(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "batch/empty")
+	// Act
+	results, err := client.NewBatch().Execute()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 0, len(results))
+}
+
+func TestBatchAddInvalidPayload(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "batch/invalid_payload")
+	batch := client.NewBatch()
+	// Act
+	batch.Add(ol.BatchOperation{Mutation: "tagDelete", Input: ol.TagDeleteInput{Id: id1}, Payload: &struct{ Errors []string }{}})
+	results, err := batch.Execute()
+	// Assert
+	autopilot.Assert(t, err != nil, "expected the payload to be rejected without being sent")
+	autopilot.Equals(t, "Errors field of the payload of 'tagDelete' must be []OpsLevelErrors, not []string", results[0].Err.Error())
+}
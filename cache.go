@@ -285,15 +285,28 @@ func (c *Cacher) CacheAll(client *Client) {
 	c.mutex.Unlock()
 }
 
-var Cache = &Cacher{
-	mutex:        sync.Mutex{},
-	Tiers:        make(map[string]Tier),
-	Lifecycles:   make(map[string]Lifecycle),
-	Teams:        make(map[string]Team),
-	Categories:   make(map[string]Category),
-	Levels:       make(map[string]Level),
-	Filters:      make(map[string]Filter),
-	Integrations: make(map[string]Integration),
-	Repositories: make(map[string]Repository),
-	InfraSchemas: make(map[string]InfrastructureResourceSchema),
+// NewCacher returns an empty cache, e.g. for an account other than the one the global Cache is used with
+func NewCacher() *Cacher {
+	return &Cacher{
+		mutex:        sync.Mutex{},
+		Tiers:        make(map[string]Tier),
+		Lifecycles:   make(map[string]Lifecycle),
+		Teams:        make(map[string]Team),
+		Categories:   make(map[string]Category),
+		Levels:       make(map[string]Level),
+		Filters:      make(map[string]Filter),
+		Integrations: make(map[string]Integration),
+		Repositories: make(map[string]Repository),
+		InfraSchemas: make(map[string]InfrastructureResourceSchema),
+	}
+}
+
+var Cache = NewCacher()
+
+// Cacher returns the cache of the client's account when it was registered with an AccountRegistry, otherwise the global Cache
+func (client *Client) Cacher() *Cacher {
+	if client.cache != nil {
+		return client.cache
+	}
+	return Cache
 }
@@ -0,0 +1,305 @@
+package opslevel
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/relvacode/iso8601"
+)
+
+// Lightweight Check struct used to make check result calls return less data
+type CheckId struct {
+	Category Category  `graphql:"category"`
+	Id       ID        `graphql:"id"`
+	Level    Level     `graphql:"level"`
+	Name     string    `graphql:"name"`
+	Type     CheckType `graphql:"type"`
+}
+
+type CheckResult struct {
+	Check       CheckId      `graphql:"check"`
+	LastUpdated iso8601.Time `graphql:"lastUpdated"`
+	Message     string       `graphql:"message"`
+	Status      CheckStatus  `graphql:"status"`
+}
+
+type CheckResultConnection struct {
+	Nodes    []CheckResult
+	PageInfo PageInfo
+}
+
+type CheckResultsByLevel struct {
+	Level Level                 `graphql:"level"`
+	Items CheckResultConnection `graphql:"items"`
+}
+
+type ServiceCheckResults struct {
+	Service      ServiceId
+	CurrentLevel Level
+	Results      []CheckResult
+}
+
+//#region Helpers
+
+// Get Given a 'check' id returns its result for the service
+func (s *ServiceCheckResults) Get(check ID) *CheckResult {
+	for _, result := range s.Results {
+		if result.Check.Id == check {
+			return &result
+		}
+	}
+	return nil
+}
+
+func (s *ServiceCheckResults) WithStatus(status CheckStatus) []CheckResult {
+	var output []CheckResult
+	for _, result := range s.Results {
+		if result.Status == status {
+			output = append(output, result)
+		}
+	}
+	return output
+}
+
+func (s *ServiceCheckResults) Failing() []CheckResult {
+	return s.WithStatus(CheckStatusFailed)
+}
+
+// FailingByCategory returns the failing checks keyed by category name
+func (s *ServiceCheckResults) FailingByCategory() map[string][]CheckResult {
+	output := map[string][]CheckResult{}
+	for _, result := range s.Failing() {
+		output[result.Check.Category.Name] = append(output[result.Check.Category.Name], result)
+	}
+	return output
+}
+
+// FailingByLevel returns the failing checks keyed by level alias
+func (s *ServiceCheckResults) FailingByLevel() map[string][]CheckResult {
+	output := map[string][]CheckResult{}
+	for _, result := range s.Failing() {
+		output[result.Check.Level.Alias] = append(output[result.Check.Level.Alias], result)
+	}
+	return output
+}
+
+// NextLevel Given the rubric 'levels' returns the level after the service's current level
+// and every non passing check at or below it that must pass to reach it.
+// Returns a nil level if the service is already at the highest level.
+func (s *ServiceCheckResults) NextLevel(levels []Level) (*Level, []CheckResult) {
+	sorted := make([]Level, len(levels))
+	copy(sorted, levels)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+
+	var next *Level
+	for _, level := range sorted {
+		if level.Index > s.CurrentLevel.Index {
+			next = &level
+			break
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+	var output []CheckResult
+	for _, result := range s.Results {
+		if result.Status != CheckStatusPassed && result.Check.Level.Index <= next.Index {
+			output = append(output, result)
+		}
+	}
+	return next, output
+}
+
+//#endregion
+
+//#region Retrieve
+
+// checkResultsChunkSize is the number of services ListCheckResults reads per request
+const checkResultsChunkSize = 20
+
+// ServiceCheckResult is the result of a check on a service
+type ServiceCheckResult struct {
+	Service ServiceId
+	Result  CheckResult
+}
+
+// serviceCheckResultsNode is a service with the first page of the check results of each level
+type serviceCheckResultsNode struct {
+	ServiceId
+	MaturityReport struct {
+		OverallLevel Level
+	}
+	CheckResults struct {
+		ByLevel struct {
+			Nodes []struct {
+				Level Level                 `graphql:"level"`
+				Items CheckResultConnection `graphql:"items(first: $first)"`
+			}
+		}
+	}
+}
+
+// checkResults fetches the remaining pages of each level and flattens the results
+func (client *Client) checkResults(node *serviceCheckResultsNode) (*ServiceCheckResults, error) {
+	byLevel := make([]CheckResultsByLevel, len(node.CheckResults.ByLevel.Nodes))
+	for i, item := range node.CheckResults.ByLevel.Nodes {
+		byLevel[i] = CheckResultsByLevel{Level: item.Level, Items: item.Items}
+	}
+	if err := client.nextCheckResults(node.Id, byLevel); err != nil {
+		return nil, err
+	}
+	output := &ServiceCheckResults{
+		Service:      node.ServiceId,
+		CurrentLevel: node.MaturityReport.OverallLevel,
+	}
+	for _, level := range byLevel {
+		for _, result := range level.Items.Nodes {
+			// Fallback to the grouping's level if the check's level was not returned
+			if result.Check.Level.Id == "" {
+				result.Check.Level = level.Level
+			}
+			output.Results = append(output.Results, result)
+		}
+	}
+	return output, nil
+}
+
+// nextCheckResults appends the following pages of the levels with more results. The items of each level are a
+// connection with its own cursor, so byLevel is requested once per level, aliased 'l<index>', with that level's
+// cursor and only the matching level is read from it.
+func (client *Client) nextCheckResults(service ID, byLevel []CheckResultsByLevel) error {
+	for {
+		var pending []int
+		for i, level := range byLevel {
+			if level.Items.PageInfo.HasNextPage {
+				pending = append(pending, i)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		fields := make([]reflect.StructField, len(pending))
+		v := PayloadVariables{"service": service, "first": client.pageSize}
+		for i, index := range pending {
+			name := fmt.Sprintf("l%d", i)
+			v[name] = byLevel[index].Items.PageInfo.End
+			node := reflect.StructOf([]reflect.StructField{
+				{Name: "Level", Type: reflect.TypeOf(Level{}), Tag: `graphql:"level"`},
+				{Name: "Items", Type: reflect.TypeOf(CheckResultConnection{}), Tag: reflect.StructTag(fmt.Sprintf(`graphql:"items(after: $%s, first: $first)"`, name))},
+			})
+			fields[i] = reflect.StructField{
+				Name: fmt.Sprintf("L%d", i),
+				Type: reflect.StructOf([]reflect.StructField{{Name: "Nodes", Type: reflect.SliceOf(node)}}),
+				Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"%s: byLevel"`, name)),
+			}
+		}
+		q := reflect.New(reflect.StructOf([]reflect.StructField{{
+			Name: "Account",
+			Type: reflect.StructOf([]reflect.StructField{{
+				Name: "Service",
+				Type: reflect.StructOf([]reflect.StructField{{Name: "CheckResults", Type: reflect.StructOf(fields)}}),
+				Tag:  `graphql:"service(id: $service)"`,
+			}}),
+		}}))
+		if err := client.Query(q.Interface(), v, WithName("ServiceCheckResultsPage")); err != nil {
+			return err
+		}
+		checkResults := q.Elem().Field(0).Field(0).Field(0)
+		for i, index := range pending {
+			level := &byLevel[index]
+			nodes := checkResults.Field(i).Field(0)
+			found := false
+			for j := 0; j < nodes.Len(); j++ {
+				if nodes.Index(j).Field(0).Interface().(Level).Id != level.Level.Id {
+					continue
+				}
+				items := nodes.Index(j).Field(1).Interface().(CheckResultConnection)
+				level.Items.Nodes = append(level.Items.Nodes, items.Nodes...)
+				level.Items.PageInfo = items.PageInfo
+				found = true
+				break
+			}
+			if !found {
+				return fmt.Errorf("check results of level '%s' on service '%s' are missing from the next page", level.Level.Alias, service)
+			}
+		}
+	}
+}
+
+// GetServiceCheckResults returns every check result of the service, paginating the results of each level
+func (client *Client) GetServiceCheckResults(service ID) (*ServiceCheckResults, error) {
+	var q struct {
+		Account struct {
+			Service serviceCheckResultsNode `graphql:"service(id: $service)"`
+		}
+	}
+	v := PayloadVariables{"service": service, "first": client.pageSize}
+	if err := client.Query(&q, v, WithName("ServiceCheckResultsGet")); err != nil {
+		return nil, err
+	}
+	if q.Account.Service.Id == "" {
+		return nil, fmt.Errorf("service with ID '%s' not found", service)
+	}
+	return client.checkResults(&q.Account.Service)
+}
+
+func (client *Client) GetCheckResult(service ID, check ID) (*CheckResult, error) {
+	results, err := client.GetServiceCheckResults(service)
+	if err != nil {
+		return nil, err
+	}
+	result := results.Get(check)
+	if result == nil {
+		return nil, fmt.Errorf("check result for check '%s' on service '%s' not found", check, service)
+	}
+	return result, nil
+}
+
+// ListCheckResults returns the result of 'check' on every service it applies to. The check results of several
+// services are read per request, as fields aliased 's<index>'.
+func (client *Client) ListCheckResults(check ID) ([]ServiceCheckResult, error) {
+	services, err := ListServicesAs[ServiceId](client, nil)
+	if err != nil {
+		return nil, err
+	}
+	var output []ServiceCheckResult
+	for start := 0; start < len(services.Nodes); start += checkResultsChunkSize {
+		chunk := services.Nodes[start:min(start+checkResultsChunkSize, len(services.Nodes))]
+		fields := make([]reflect.StructField, len(chunk))
+		v := PayloadVariables{"first": client.pageSize}
+		for i, service := range chunk {
+			name := fmt.Sprintf("s%d", i)
+			v[name] = service.Id
+			fields[i] = reflect.StructField{
+				Name: fmt.Sprintf("S%d", i),
+				Type: reflect.TypeOf(serviceCheckResultsNode{}),
+				Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"%s: service(id: $%s)"`, name, name)),
+			}
+		}
+		q := reflect.New(reflect.StructOf([]reflect.StructField{{
+			Name: "Account",
+			Type: reflect.StructOf(fields),
+		}}))
+		if err := client.Query(q.Interface(), v, WithName("ServiceCheckResultsList")); err != nil {
+			return nil, err
+		}
+		account := q.Elem().Field(0)
+		for i := range chunk {
+			node := account.Field(i).Addr().Interface().(*serviceCheckResultsNode)
+			if node.Id == "" {
+				continue
+			}
+			results, err := client.checkResults(node)
+			if err != nil {
+				return nil, err
+			}
+			if result := results.Get(check); result != nil {
+				output = append(output, ServiceCheckResult{Service: results.Service, Result: *result})
+			}
+		}
+	}
+	return output, nil
+}
+
+//#endregion
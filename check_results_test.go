@@ -0,0 +1,119 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestGetServiceCheckResults(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query ServiceCheckResultsGet($first:Int!$service:ID!){account{service(id: $service){id,aliases,maturityReport{overallLevel{alias,description,id,index,name}},checkResults{byLevel{nodes{level{alias,description,id,index,name},items(first: $first){nodes{check{category{id,name},id,level{alias,description,id,index,name},name,type},lastUpdated,message,status},{{ template "pagination_request" }}}}}}}}}"`,
+		`{"service": "{{ template "id1_string" }}", "first": 100 }`,
+		`{"data": {"account": {"service": { {{ template "id1" }}, "aliases": ["example"], "maturityReport": {"overallLevel": {{ template "level_1" }} }, "checkResults": {"byLevel": {"nodes": [
+			{"level": {{ template "level_1" }}, "items": {"nodes": [
+				{"check": { {{ template "id1" }}, "name": "Has Owner", "type": "has_owner", "category": {{ template "category_1" }}, "level": {{ template "level_1" }} }, "lastUpdated": "2023-11-01T12:00:00Z", "message": "Service has an owner", "status": "passed"}
+			], {{ template "no_pagination_response" }} }},
+			{"level": {{ template "level_2" }}, "items": {"nodes": [
+				{"check": { {{ template "id2" }}, "name": "Has Runbook", "type": "has_documentation", "category": {{ template "category_1" }} }, "lastUpdated": "2023-11-01T12:00:00Z", "message": "Runbook is missing", "status": "failed"},
+				{"check": { {{ template "id3" }}, "name": "Has Repo", "type": "has_repository", "category": {{ template "category_2" }}, "level": {{ template "level_2" }} }, "lastUpdated": "2023-11-01T12:00:00Z", "message": "Repository is missing", "status": "failed"}
+			], {{ template "no_pagination_response" }} }},
+			{"level": {{ template "level_3" }}, "items": {"nodes": [
+				{"check": { {{ template "id4" }}, "name": "Has Alerts", "type": "alert_source_usage", "category": {{ template "category_2" }}, "level": {{ template "level_3" }} }, "lastUpdated": "2023-11-01T12:00:00Z", "message": "", "status": "pending"}
+			], {{ template "no_pagination_response" }} }}
+		]}}}}}}`,
+	)
+	client := BestTestClient(t, "check_results/service", testRequest)
+	// Act
+	result, err := client.GetServiceCheckResults(id1)
+	next, needed := result.NextLevel([]ol.Level{
+		{Id: id3, Alias: "example_3", Index: 3},
+		{Id: id1, Alias: "example", Index: 1},
+		{Id: id2, Alias: "example_2", Index: 2},
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 4, len(result.Results))
+	autopilot.Equals(t, "example", result.CurrentLevel.Alias)
+	autopilot.Equals(t, ol.CheckStatusPassed, result.Get(id1).Status)
+	autopilot.Equals(t, "example_2", result.Get(id2).Check.Level.Alias)
+	autopilot.Equals(t, 2, len(result.Failing()))
+	autopilot.Equals(t, 1, len(result.FailingByCategory()["Example 2"]))
+	autopilot.Equals(t, 2, len(result.FailingByLevel()["example_2"]))
+	autopilot.Equals(t, "example_2", next.Alias)
+	autopilot.Equals(t, 2, len(needed))
+}
+
+const checkResultItemsRequest = `{nodes{check{category{id,name},id,level{alias,description,id,index,name},name,type},lastUpdated,message,status},{{ template "pagination_request" }}}`
+
+const serviceCheckResultsRequest = `"query ServiceCheckResultsGet($first:Int!$service:ID!){account{service(id: $service){id,aliases,maturityReport{overallLevel{alias,description,id,index,name}},checkResults{byLevel{nodes{level{alias,description,id,index,name},items(first: $first){nodes{check{category{id,name},id,level{alias,description,id,index,name},name,type},lastUpdated,message,status},{{ template "pagination_request" }}}}}}}}}"`
+
+func TestGetServiceCheckResultsPaginatesLevels(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		serviceCheckResultsRequest,
+		`{"service": "{{ template "id1_string" }}", "first": 100 }`,
+		`{"data": {"account": {"service": { {{ template "id1" }}, "maturityReport": {"overallLevel": {{ template "level_1" }} }, "checkResults": {"byLevel": {"nodes": [
+			{"level": {{ template "level_1" }}, "items": {"nodes": [
+				{"check": { {{ template "id1" }}, "name": "Has Owner" }, "status": "passed"}
+			], {{ template "pagination_initial_pageInfo_response" }} }},
+			{"level": {{ template "level_2" }}, "items": {"nodes": [
+				{"check": { {{ template "id3" }}, "name": "Has Repo" }, "status": "failed"}
+			], "pageInfo": {"hasNextPage": true, "hasPreviousPage": false, "startCursor": "MQ", "endCursor": "Mg"} }}
+		]}}}}}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"query ServiceCheckResultsPage($first:Int!$l0:String!$l1:String!$service:ID!){account{service(id: $service){checkResults{l0: byLevel{nodes{level{alias,description,id,index,name},items(after: $l0, first: $first)`+checkResultItemsRequest+`}},l1: byLevel{nodes{level{alias,description,id,index,name},items(after: $l1, first: $first)`+checkResultItemsRequest+`}}}}}}"`,
+		`{"service": "{{ template "id1_string" }}", "first": 100, "l0": "OA", "l1": "Mg" }`,
+		`{"data": {"account": {"service": {"checkResults": {
+			"l0": {"nodes": [
+				{"level": {{ template "level_1" }}, "items": {"nodes": [{"check": { {{ template "id2" }}, "name": "Has Runbook" }, "status": "failed"}], {{ template "no_pagination_response" }} }},
+				{"level": {{ template "level_2" }}, "items": {"nodes": [{"check": { {{ template "id3" }}, "name": "Has Repo" }, "status": "failed"}], {{ template "no_pagination_response" }} }}
+			]},
+			"l1": {"nodes": [
+				{"level": {{ template "level_1" }}, "items": {"nodes": [], {{ template "no_pagination_response" }} }},
+				{"level": {{ template "level_2" }}, "items": {"nodes": [{"check": { {{ template "id4" }}, "name": "Has Alerts" }, "status": "pending"}], {{ template "no_pagination_response" }} }}
+			]}
+		}}}}}`,
+	)
+	client := BestTestClient(t, "check_results/paginated", testRequestOne, testRequestTwo)
+	// Act
+	result, err := client.GetServiceCheckResults(id1)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 4, len(result.Results))
+	autopilot.Equals(t, ol.CheckStatusFailed, result.Get(id2).Status)
+	autopilot.Equals(t, "example", result.Get(id2).Check.Level.Alias)
+	autopilot.Equals(t, ol.CheckStatusPending, result.Get(id4).Status)
+}
+
+func TestListCheckResults(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"query ServiceList($after:String!$first:Int!){account{services(after: $after, first: $first){nodes{id,aliases},{{ template "pagination_request" }},totalCount}}}"`,
+		`{ {{ template "first_page_variables" }} }`,
+		`{"data": {"account": {"services": {"nodes": [{ {{ template "id1" }} }, { {{ template "id2" }} }], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"query ServiceCheckResultsList($first:Int!$s0:ID!$s1:ID!){account{s0: service(id: $s0){id,aliases,maturityReport{overallLevel{alias,description,id,index,name}},checkResults{byLevel{nodes{level{alias,description,id,index,name},items(first: $first)`+checkResultItemsRequest+`}}}},s1: service(id: $s1){id,aliases,maturityReport{overallLevel{alias,description,id,index,name}},checkResults{byLevel{nodes{level{alias,description,id,index,name},items(first: $first)`+checkResultItemsRequest+`}}}}}}"`,
+		`{"first": 100, "s0": "{{ template "id1_string" }}", "s1": "{{ template "id2_string" }}" }`,
+		`{"data": {"account": {
+			"s0": { {{ template "id1" }}, "checkResults": {"byLevel": {"nodes": [
+				{"level": {{ template "level_1" }}, "items": {"nodes": [{"check": { {{ template "id3" }}, "name": "Has Owner" }, "status": "passed"}], {{ template "no_pagination_response" }} }}
+			]}}},
+			"s1": { {{ template "id2" }}, "checkResults": {"byLevel": {"nodes": [
+				{"level": {{ template "level_1" }}, "items": {"nodes": [{"check": { {{ template "id4" }}, "name": "Has Repo" }, "status": "failed"}], {{ template "no_pagination_response" }} }}
+			]}}}
+		}}}`,
+	)
+	client := BestTestClient(t, "check_results/check", testRequestOne, testRequestTwo)
+	// Act
+	result, err := client.ListCheckResults(id3)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result))
+	autopilot.Equals(t, id1, result[0].Service.Id)
+	autopilot.Equals(t, ol.CheckStatusPassed, result[0].Result.Status)
+}
@@ -1,7 +1,11 @@
 package opslevel
 
 import (
+	"crypto/tls"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"runtime"
 	"strings"
@@ -10,11 +14,19 @@ import (
 
 type ClientSettings struct {
 	url      string
-	token    string
+	token    TokenProvider
 	timeout  time.Duration
 	retries  int
 	headers  map[string]string
-	pageSize int // Only Used by GQL
+	pageSize int       // Only Used by GQL
+	dryRun   bool      // Only Used by GQL
+	journal  io.Writer // Only Used by GQL
+
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	proxy       *url.URL
+	tlsConfig   *tls.Config
+	connections ConnectionPool
 }
 
 type Option func(*ClientSettings)
@@ -22,7 +34,7 @@ type Option func(*ClientSettings)
 func newClientSettings(options ...Option) *ClientSettings {
 	settings := &ClientSettings{
 		url:     "https://app.opslevel.com",
-		token:   os.Getenv("OPSLEVEL_API_TOKEN"),
+		token:   StaticTokenProvider(os.Getenv("OPSLEVEL_API_TOKEN")),
 		timeout: time.Second * 10,
 		retries: 10,
 
@@ -39,8 +51,13 @@ func newClientSettings(options ...Option) *ClientSettings {
 }
 
 func SetAPIToken(apiToken string) Option {
+	return SetTokenProvider(StaticTokenProvider(apiToken))
+}
+
+// SetTokenProvider makes the GQL client ask 'provider' for the API token of every request
+func SetTokenProvider(provider TokenProvider) Option {
 	return func(c *ClientSettings) {
-		c.token = apiToken
+		c.token = provider
 	}
 }
 
@@ -84,6 +101,59 @@ func SetAPIVisibility(visibility string) Option {
 	return SetHeader("GraphQL-Visibility", visibility)
 }
 
+// SetDryRun makes the GQL client record mutations instead of sending them - queries are still sent.
+// The recorded mutations are available from client.DryRunMutations and client.DryRunReport
+func SetDryRun(enabled bool) Option {
+	return func(c *ClientSettings) {
+		c.dryRun = enabled
+	}
+}
+
+// SetJournal makes the GQL client write every mutation it sends to 'writer' as a JSON line with its variables,
+// payload and, for service and team updates and deletes, the prior state of the entity - see ReadJournal and client.Undo
+func SetJournal(writer io.Writer) Option {
+	return func(c *ClientSettings) {
+		c.journal = writer
+	}
+}
+
+// SetHTTPClient makes the GQL and REST clients send requests with 'client' - the other transport options and,
+// for the REST client, SetTimeout are ignored so the client is used as configured
+func SetHTTPClient(client *http.Client) Option {
+	return func(c *ClientSettings) {
+		c.httpClient = client
+	}
+}
+
+// SetTransport makes the GQL and REST clients send requests through 'transport'. SetProxy, SetTLSConfig and
+// SetConnectionPool are applied to a copy of it when it is an *http.Transport
+func SetTransport(transport http.RoundTripper) Option {
+	return func(c *ClientSettings) {
+		c.transport = transport
+	}
+}
+
+// SetProxy sends requests through the proxy at 'proxy' instead of the one from the environment
+func SetProxy(proxy *url.URL) Option {
+	return func(c *ClientSettings) {
+		c.proxy = proxy
+	}
+}
+
+// SetTLSConfig sets the TLS configuration of connections to the API, e.g. from NewTLSConfig for a custom CA bundle or mTLS
+func SetTLSConfig(config *tls.Config) Option {
+	return func(c *ClientSettings) {
+		c.tlsConfig = config
+	}
+}
+
+// SetConnectionPool limits the connections the GQL and REST clients keep open to the API
+func SetConnectionPool(pool ConnectionPool) Option {
+	return func(c *ClientSettings) {
+		c.connections = pool
+	}
+}
+
 func SetPageSize(size int) Option {
 	return func(c *ClientSettings) {
 		c.pageSize = size
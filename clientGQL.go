@@ -2,6 +2,7 @@ package opslevel
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -13,6 +14,9 @@ import (
 type Client struct {
 	pageSize graphql.Int
 	client   *graphql.Client
+	dryRun   *dryRunRecorder
+	journal  *journal
+	cache    *Cacher
 }
 
 // Deprecated: Use NewGQLClient instead
@@ -27,8 +31,14 @@ func NewGQLClient(options ...Option) *Client {
 	retryClient := retryablehttp.NewClient()
 	retryClient.RetryMax = settings.retries
 	retryClient.Logger = nil
+	if settings.httpClient != nil {
+		retryClient.HTTPClient = settings.httpClient
+	} else if transport := settings.roundTripper(); transport != nil {
+		retryClient.HTTPClient.Transport = transport
+	}
 
 	standardClient := retryClient.StandardClient()
+	standardClient.Transport = &tokenTransport{base: standardClient.Transport, provider: settings.token}
 	var url string
 	if strings.Contains(settings.url, "/LOCAL_TESTING/") {
 		url = settings.url
@@ -36,19 +46,24 @@ func NewGQLClient(options ...Option) *Client {
 		url = fmt.Sprintf("%s/graphql", settings.url)
 	}
 
-	auth := fmt.Sprintf("Bearer %s", settings.token)
 	modifier := graphql.RequestModifier(
 		func(r *http.Request) {
-			r.Header.Add("Authorization", auth)
 			for key, value := range settings.headers {
 				r.Header.Add(key, value)
 			}
 		})
 
-	return &Client{
+	client := &Client{
 		pageSize: graphql.Int(settings.pageSize),
 		client:   graphql.NewClient(url, standardClient).WithRequestModifier(modifier),
 	}
+	if settings.dryRun {
+		client.dryRun = &dryRunRecorder{}
+	}
+	if settings.journal != nil {
+		client.journal = &journal{encoder: json.NewEncoder(settings.journal)}
+	}
+	return client
 }
 
 func (client *Client) InitialPageVariables() PayloadVariables {
@@ -79,6 +94,12 @@ func (client *Client) Mutate(m interface{}, variables map[string]interface{}, op
 }
 
 func (client *Client) MutateCTX(ctx context.Context, m interface{}, variables map[string]interface{}, options ...graphql.Option) error {
+	if client.dryRun != nil {
+		return client.dryRun.record(m, variables, options...)
+	}
+	if client.journal != nil {
+		return client.journal.mutate(ctx, client, m, variables, options...)
+	}
 	return client.client.Mutate(ctx, m, variables, options...)
 }
 
@@ -10,13 +10,17 @@ type RestResponse struct {
 }
 
 func NewRestClient(options ...Option) *resty.Client {
-	client := resty.New()
 	settings := newClientSettings(options...)
+	var client *resty.Client
+	if settings.httpClient != nil {
+		client = resty.NewWithClient(settings.httpClient)
+	} else {
+		client = resty.New().SetTransport(settings.roundTripper()).SetTimeout(settings.timeout)
+	}
 	client.SetBaseURL(settings.url)
 	client.SetHeader("Accept", "application/json")
 	for key, value := range settings.headers {
 		client.SetHeader(key, value)
 	}
-	client.SetTimeout(settings.timeout)
 	return client
 }
@@ -0,0 +1,130 @@
+package opslevel
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+// DryRunMutation is a mutation a dry run client recorded instead of sending
+type DryRunMutation struct {
+	Operation string // e.g. 'ServiceCreate'
+	Query     string
+	Variables PayloadVariables // As they would have been sent - String redacts the sensitive ones
+}
+
+// String renders the mutation as its operation followed by one line of JSON per variable, with the fields
+// the journal redacts replaced by RedactedValue
+func (m DryRunMutation) String() string {
+	var sb strings.Builder
+	sb.WriteString(m.Operation)
+	variables, err := redactVariables(m.Operation, m.Variables)
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("\n    variables could not be rendered: %s", err))
+		return sb.String()
+	}
+	keys := make([]string, 0, len(variables))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, _ := json.Marshal(variables[key])
+		sb.WriteString(fmt.Sprintf("\n    %s: %s", key, value))
+	}
+	return sb.String()
+}
+
+type dryRunRecorder struct {
+	mutex     sync.Mutex
+	mutations []DryRunMutation
+}
+
+func (r *dryRunRecorder) record(m interface{}, variables map[string]interface{}, options ...graphql.Option) error {
+	query, err := graphql.ConstructMutation(m, variables, options...)
+	if err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.mutations = append(r.mutations, DryRunMutation{
+		Operation: operationName(query),
+		Query:     query,
+		Variables: variables,
+	})
+	syntheticPayload(m, len(r.mutations))
+	return nil
+}
+
+// operationName returns the name of a constructed query or mutation, e.g. 'ServiceCreate'
+func operationName(query string) string {
+	operation := strings.TrimPrefix(strings.TrimPrefix(query, "mutation"), "query")
+	if i := strings.IndexAny(operation, "({"); i != -1 {
+		operation = operation[:i]
+	}
+	return strings.TrimSpace(operation)
+}
+
+// syntheticPayload gives each entity in the mutation's payloads a placeholder ID so callers can tell it apart from
+// an entity that was not returned - everything else is left as the zero value
+func syntheticPayload(m interface{}, index int) {
+	value := reflect.ValueOf(m)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return
+	}
+	id := reflect.ValueOf(ID(fmt.Sprintf("dry-run-%d", index)))
+	for _, payload := range structFields(value.Elem()) {
+		for _, entity := range structFields(payload) {
+			if field := entity.FieldByName("Id"); field.IsValid() && field.CanSet() && field.Type() == id.Type() {
+				field.Set(id)
+			}
+		}
+	}
+}
+
+// structFields returns the struct and non-nil pointer to struct fields of 'value'
+func structFields(value reflect.Value) []reflect.Value {
+	var output []reflect.Value
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Struct && field.CanSet() {
+			if field.IsNil() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			field = field.Elem()
+		}
+		if field.Kind() == reflect.Struct {
+			output = append(output, field)
+		}
+	}
+	return output
+}
+
+// DryRun returns true if the client records mutations instead of sending them
+func (client *Client) DryRun() bool {
+	return client.dryRun != nil
+}
+
+// DryRunMutations returns the mutations recorded by a dry run client in the order they were made
+func (client *Client) DryRunMutations() []DryRunMutation {
+	if client.dryRun == nil {
+		return nil
+	}
+	client.dryRun.mutex.Lock()
+	defer client.dryRun.mutex.Unlock()
+	return append([]DryRunMutation{}, client.dryRun.mutations...)
+}
+
+// DryRunReport returns the recorded mutations as a numbered change list
+func (client *Client) DryRunReport() string {
+	var sb strings.Builder
+	for i, mutation := range client.DryRunMutations() {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, mutation))
+	}
+	return sb.String()
+}
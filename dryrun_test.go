@@ -0,0 +1,70 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestDryRunRecordsMutations(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query ServiceGet($service:String!){account{service(alias: $service){id,aliases}}}"`,
+		`{"service": "foo"}`,
+		`{"data": { "account": { "service": { {{ template "id1" }}, "aliases": ["foo"] }}}}`,
+	)
+	url := RegisterPaginatedEndpoint(t, "dryrun/mutations", testRequest)
+	client := ol.NewGQLClient(ol.SetAPIToken("x"), ol.SetMaxRetries(0), ol.SetURL(url), ol.SetDryRun(true))
+	// Act
+	service, err := client.GetServiceIdWithAlias("foo")
+	autopilot.Ok(t, err)
+	tag, err := client.CreateTag(ol.TagCreateInput{Id: service.Id, Key: "env", Value: "prod"})
+	autopilot.Ok(t, err)
+	err = client.DeleteTeam(id2)
+	autopilot.Ok(t, err)
+	mutations := client.DryRunMutations()
+	// Assert
+	autopilot.Equals(t, true, client.DryRun())
+	autopilot.Equals(t, id1, service.Id)
+	autopilot.Equals(t, ol.ID("dry-run-1"), tag.Id)
+	autopilot.Equals(t, 2, len(mutations))
+	autopilot.Equals(t, "TagCreate", mutations[0].Operation)
+	autopilot.Equals(t, "mutation TagCreate($input:TagCreateInput!){tagCreate(input: $input){tag{id,key,value},errors{message,path}}}", mutations[0].Query)
+	autopilot.Equals(t, "TeamDelete", mutations[1].Operation)
+	autopilot.Equals(t, ol.TeamDeleteInput{Id: id2}, mutations[1].Variables["input"])
+	autopilot.Equals(t, `1. TagCreate
+    input: {"id":"`+string(id1)+`","key":"env","value":"prod"}
+2. TeamDelete
+    input: {"id":"`+string(id2)+`"}
+`, client.DryRunReport())
+}
+
+func TestDryRunReportRedactsSecrets(t *testing.T) {
+	// Arrange
+	client := ol.NewGQLClient(ol.SetAPIToken("x"), ol.SetDryRun(true))
+	// Act
+	_, err := client.CreateIntegrationNewRelic(ol.NewRelicIntegrationInput{ApiKey: ol.NewString("123456789"), AccountKey: ol.NewString("XXXX")})
+	autopilot.Ok(t, err)
+	_, err = client.CreateSecret("alias1", ol.SecretInput{Owner: ol.IdentifierInput{Id: id2}, Value: "my-secret"})
+	autopilot.Ok(t, err)
+	// Assert
+	autopilot.Equals(t, `1. NewRelicIntegrationCreate
+    input: {"accountKey":"XXXX","apiKey":"[REDACTED]"}
+2. SecretsVaultsSecretCreate
+    alias: "alias1"
+    input: {"owner":{"id":"`+string(id2)+`"},"value":"[REDACTED]"}
+`, client.DryRunReport())
+	autopilot.Equals(t, "my-secret", client.DryRunMutations()[1].Variables["input"].(ol.SecretInput).Value)
+}
+
+func TestDryRunDisabled(t *testing.T) {
+	// Arrange
+	client := BestTestClient(t, "dryrun/disabled")
+	// Act
+	mutations := client.DryRunMutations()
+	// Assert
+	autopilot.Equals(t, false, client.DryRun())
+	autopilot.Equals(t, 0, len(mutations))
+	autopilot.Equals(t, "", client.DryRunReport())
+}
@@ -364,6 +364,36 @@ var AllCustomActionsTriggerEventStatusEnum = []string{
 	string(CustomActionsTriggerEventStatusEnumFailure),
 }
 
+// EventIntegrationEnum represents the type of event integration.
+type EventIntegrationEnum string
+
+const (
+	EventIntegrationEnumApidoc        EventIntegrationEnum = "apiDoc"        // API Docs integration.
+	EventIntegrationEnumArgocd        EventIntegrationEnum = "argocd"        // Argo CD deploy integration.
+	EventIntegrationEnumDatadogcheck  EventIntegrationEnum = "datadogCheck"  // Datadog Check integration.
+	EventIntegrationEnumDeploy        EventIntegrationEnum = "deploy"        // Deploy integration.
+	EventIntegrationEnumFlux          EventIntegrationEnum = "flux"          // Flux integration.
+	EventIntegrationEnumGeneric       EventIntegrationEnum = "generic"       // Generic integration.
+	EventIntegrationEnumGithubactions EventIntegrationEnum = "githubActions" // GitHub Actions integration.
+	EventIntegrationEnumJenkins       EventIntegrationEnum = "jenkins"       // Jenkins integration.
+	EventIntegrationEnumNewreliccheck EventIntegrationEnum = "newRelicCheck" // New Relic Check integration.
+	EventIntegrationEnumSonarqube     EventIntegrationEnum = "sonarqube"     // SonarQube integration.
+)
+
+// All EventIntegrationEnum as []string
+var AllEventIntegrationEnum = []string{
+	string(EventIntegrationEnumApidoc),
+	string(EventIntegrationEnumArgocd),
+	string(EventIntegrationEnumDatadogcheck),
+	string(EventIntegrationEnumDeploy),
+	string(EventIntegrationEnumFlux),
+	string(EventIntegrationEnumGeneric),
+	string(EventIntegrationEnumGithubactions),
+	string(EventIntegrationEnumJenkins),
+	string(EventIntegrationEnumNewreliccheck),
+	string(EventIntegrationEnumSonarqube),
+}
+
 // FrequencyTimeScale represents the time scale type for the frequency.
 type FrequencyTimeScale string
 
@@ -0,0 +1,299 @@
+package opslevel
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InfraDataError is a single problem with InfraInput.Data located by its field path, e.g. 'endpoints[0].port'
+type InfraDataError struct {
+	Path    string
+	Message string
+}
+
+func (e InfraDataError) String() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+type InfraDataErrors []InfraDataError
+
+func (e InfraDataErrors) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.String())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validate checks 'data' against the schema locally supporting the JSON Schema keywords used by infrastructure schemas:
+// type, properties, required, additionalProperties, items, enum, pattern, minimum, maximum, minLength, maxLength, minItems and maxItems
+func (s *InfrastructureResourceSchema) Validate(data map[string]any) error {
+	var errs InfraDataErrors
+	validateInfraValue("", normalizeInfraValue(s.Schema), normalizeInfraValue(data), &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Coerce converts values in 'data' to the types the schema expects where it is lossless, e.g. "42" to 42 for an integer
+// or true to "true" for a string. Values that cannot be converted are left as is for Validate to report.
+func (s *InfrastructureResourceSchema) Coerce(data map[string]any) map[string]any {
+	output, _ := coerceInfraValue(normalizeInfraValue(s.Schema), normalizeInfraValue(data)).(map[string]any)
+	return output
+}
+
+// ValidateInfraInput looks up the input's schema in the cached InfraSchemas and validates its Data,
+// optionally coercing the Data in place first
+func (c *Cacher) ValidateInfraInput(input *InfraInput, coerce bool) error {
+	schema, ok := c.TryGetInfrastructureSchema(input.Schema)
+	if !ok {
+		return fmt.Errorf("InfrastructureResourceSchema '%s' not found in cache", input.Schema)
+	}
+	if coerce {
+		input.Data = schema.Coerce(input.Data)
+	}
+	return schema.Validate(input.Data)
+}
+
+// normalizeInfraValue round trips Go values through JSON so data and schemas built in Go have the same types as API responses
+func normalizeInfraValue(data map[string]any) map[string]any {
+	if data == nil {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+	var output map[string]any
+	if err := json.Unmarshal(raw, &output); err != nil {
+		return data
+	}
+	return output
+}
+
+func infraSchemaTypes(schema map[string]any) []string {
+	switch value := schema["type"].(type) {
+	case string:
+		return []string{value}
+	case []any:
+		var output []string
+		for _, item := range value {
+			if text, ok := item.(string); ok {
+				output = append(output, text)
+			}
+		}
+		return output
+	}
+	return nil
+}
+
+func infraValueType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func infraTypeMatches(expected []string, actual string) bool {
+	if len(expected) == 0 {
+		return true
+	}
+	for _, t := range expected {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func joinInfraPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func infraSchemaNumber(schema map[string]any, key string) (float64, bool) {
+	value, ok := schema[key].(float64)
+	return value, ok
+}
+
+func validateInfraValue(path string, schema map[string]any, value any, errs *InfraDataErrors) {
+	types := infraSchemaTypes(schema)
+	actual := infraValueType(value)
+	if !infraTypeMatches(types, actual) {
+		*errs = append(*errs, InfraDataError{Path: path, Message: fmt.Sprintf("expected %s got %s", strings.Join(types, " or "), actual)})
+		return
+	}
+	if options, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, option := range options {
+			if reflect.DeepEqual(option, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*errs = append(*errs, InfraDataError{Path: path, Message: fmt.Sprintf("value %v is not one of %v", value, options)})
+		}
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		validateInfraObject(path, schema, v, errs)
+	case []any:
+		if minimum, ok := infraSchemaNumber(schema, "minItems"); ok && float64(len(v)) < minimum {
+			*errs = append(*errs, InfraDataError{Path: path, Message: fmt.Sprintf("expected at least %v items got %d", minimum, len(v))})
+		}
+		if maximum, ok := infraSchemaNumber(schema, "maxItems"); ok && float64(len(v)) > maximum {
+			*errs = append(*errs, InfraDataError{Path: path, Message: fmt.Sprintf("expected at most %v items got %d", maximum, len(v))})
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				validateInfraValue(fmt.Sprintf("%s[%d]", path, i), items, item, errs)
+			}
+		}
+	case string:
+		if minimum, ok := infraSchemaNumber(schema, "minLength"); ok && float64(len(v)) < minimum {
+			*errs = append(*errs, InfraDataError{Path: path, Message: fmt.Sprintf("expected a minimum length of %v got %d", minimum, len(v))})
+		}
+		if maximum, ok := infraSchemaNumber(schema, "maxLength"); ok && float64(len(v)) > maximum {
+			*errs = append(*errs, InfraDataError{Path: path, Message: fmt.Sprintf("expected a maximum length of %v got %d", maximum, len(v))})
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				*errs = append(*errs, InfraDataError{Path: path, Message: fmt.Sprintf("'%s' does not match pattern '%s'", v, pattern)})
+			}
+		}
+	case float64:
+		if minimum, ok := infraSchemaNumber(schema, "minimum"); ok && v < minimum {
+			*errs = append(*errs, InfraDataError{Path: path, Message: fmt.Sprintf("%v is less than the minimum %v", v, minimum)})
+		}
+		if maximum, ok := infraSchemaNumber(schema, "maximum"); ok && v > maximum {
+			*errs = append(*errs, InfraDataError{Path: path, Message: fmt.Sprintf("%v is greater than the maximum %v", v, maximum)})
+		}
+	}
+}
+
+func validateInfraObject(path string, schema map[string]any, value map[string]any, errs *InfraDataErrors) {
+	if required, ok := schema["required"].([]any); ok {
+		for _, item := range required {
+			key, _ := item.(string)
+			if _, ok := value[key]; !ok {
+				*errs = append(*errs, InfraDataError{Path: joinInfraPath(path, key), Message: "is required"})
+			}
+		}
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if property, ok := properties[key].(map[string]any); ok {
+			validateInfraValue(joinInfraPath(path, key), property, value[key], errs)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				*errs = append(*errs, InfraDataError{Path: joinInfraPath(path, key), Message: "is not a supported property"})
+			}
+		case map[string]any:
+			validateInfraValue(joinInfraPath(path, key), additional, value[key], errs)
+		}
+	}
+}
+
+func coerceInfraValue(schema map[string]any, value any) any {
+	types := infraSchemaTypes(schema)
+	if infraTypeMatches(types, infraValueType(value)) {
+		switch v := value.(type) {
+		case map[string]any:
+			properties, _ := schema["properties"].(map[string]any)
+			for key, item := range v {
+				if property, ok := properties[key].(map[string]any); ok {
+					v[key] = coerceInfraValue(property, item)
+				}
+			}
+		case []any:
+			if items, ok := schema["items"].(map[string]any); ok {
+				for i, item := range v {
+					v[i] = coerceInfraValue(items, item)
+				}
+			}
+		}
+		return value
+	}
+	for _, t := range types {
+		if coerced, ok := coerceInfraScalar(t, value); ok {
+			if t == "array" {
+				return coerceInfraValue(schema, coerced)
+			}
+			return coerced
+		}
+	}
+	return value
+}
+
+func coerceInfraScalar(target string, value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		text := strings.TrimSpace(v)
+		switch target {
+		case "integer":
+			if parsed, err := strconv.ParseInt(text, 10, 64); err == nil {
+				return float64(parsed), true
+			}
+		case "number":
+			if parsed, err := strconv.ParseFloat(text, 64); err == nil {
+				return parsed, true
+			}
+		case "boolean":
+			if parsed, err := strconv.ParseBool(text); err == nil {
+				return parsed, true
+			}
+		case "array":
+			return []any{v}, true
+		}
+	case float64:
+		switch target {
+		case "string":
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		case "array":
+			return []any{v}, true
+		}
+	case bool:
+		switch target {
+		case "string":
+			return strconv.FormatBool(v), true
+		case "array":
+			return []any{v}, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,87 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+var testInfraSchema = ol.InfrastructureResourceSchema{
+	Type: "Database",
+	Schema: ol.NewJSON(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string", "minLength": 1},
+    "engine": {"type": "string", "enum": ["postgres", "mysql"]},
+    "replicas": {"type": "integer", "minimum": 1},
+    "encrypted": {"type": "boolean"},
+    "zones": {"type": "array", "items": {"type": "string"}},
+    "endpoints": {"type": "array", "items": {
+      "type": "object",
+      "required": ["port"],
+      "properties": {"host": {"type": "string"}, "port": {"type": "integer", "maximum": 65535}}
+    }}
+  }
+}`),
+}
+
+func TestInfraSchemaValidate(t *testing.T) {
+	// Arrange
+	data := map[string]any{
+		"engine":    "oracle",
+		"replicas":  0,
+		"encrypted": "yes",
+		"zones":     []string{"us-east-1a"},
+		"endpoints": []any{map[string]any{"host": "db"}, map[string]any{"port": 70000}},
+		"size":      "large",
+	}
+	// Act
+	err := testInfraSchema.Validate(data)
+	// Assert
+	autopilot.Equals(t, ol.InfraDataErrors{
+		{Path: "name", Message: "is required"},
+		{Path: "encrypted", Message: "expected boolean got string"},
+		{Path: "endpoints[0].port", Message: "is required"},
+		{Path: "endpoints[1].port", Message: "70000 is greater than the maximum 65535"},
+		{Path: "engine", Message: "value oracle is not one of [postgres mysql]"},
+		{Path: "replicas", Message: "0 is less than the minimum 1"},
+		{Path: "size", Message: "is not a supported property"},
+	}, err)
+}
+
+func TestInfraSchemaCoerce(t *testing.T) {
+	// Arrange
+	ol.Cache.InfraSchemas["Database"] = testInfraSchema
+	defer delete(ol.Cache.InfraSchemas, "Database")
+	input := ol.InfraInput{
+		Schema: "Database",
+		Data: map[string]any{
+			"name":      12345,
+			"replicas":  "3",
+			"encrypted": "true",
+			"zones":     "us-east-1a",
+			"endpoints": []any{map[string]any{"port": "5432"}},
+		},
+	}
+	// Act
+	err := ol.Cache.ValidateInfraInput(&input, true)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, map[string]any{
+		"name":      "12345",
+		"replicas":  float64(3),
+		"encrypted": true,
+		"zones":     []any{"us-east-1a"},
+		"endpoints": []any{map[string]any{"port": float64(5432)}},
+	}, input.Data)
+}
+
+func TestValidateInfraInputMissingSchema(t *testing.T) {
+	// Act
+	err := ol.Cache.ValidateInfraInput(&ol.InfraInput{Schema: "Unknown"}, false)
+	// Assert
+	autopilot.Equals(t, "InfrastructureResourceSchema 'Unknown' not found in cache", err.Error())
+}
@@ -0,0 +1,407 @@
+package opslevel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// TerraformState is the subset of a terraform.tfstate (version 4) file needed to import infrastructure
+type TerraformState struct {
+	Version   int                      `json:"version"`
+	Resources []TerraformStateResource `json:"resources"`
+}
+
+type TerraformStateResource struct {
+	Module    string                   `json:"module,omitempty"`
+	Mode      string                   `json:"mode"`
+	Type      string                   `json:"type"`
+	Name      string                   `json:"name"`
+	Provider  string                   `json:"provider"`
+	Instances []TerraformStateInstance `json:"instances"`
+}
+
+type TerraformStateInstance struct {
+	IndexKey   any            `json:"index_key,omitempty"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// TerraformResourceMapping describes how a terraform resource type becomes an OpsLevel infrastructure resource
+type TerraformResourceMapping struct {
+	Schema          string            // OpsLevel infrastructure schema type, e.g. "Database"
+	NameAttributes  []string          // First attribute found is used as the resource name
+	AliasAttributes []string          // Every attribute found is added as an alias - the first is used to match existing resources
+	Data            map[string]string // OpsLevel data field to terraform attribute
+}
+
+var DefaultTerraformResourceMappings = map[string]TerraformResourceMapping{
+	"aws_db_instance": {
+		Schema:          "Database",
+		NameAttributes:  []string{"identifier", "db_name"},
+		AliasAttributes: []string{"arn"},
+		Data:            map[string]string{"engine": "engine", "engine_version": "engine_version", "external_id": "arn", "availability_zone": "availability_zone"},
+	},
+	"aws_rds_cluster": {
+		Schema:          "Database",
+		NameAttributes:  []string{"cluster_identifier"},
+		AliasAttributes: []string{"arn"},
+		Data:            map[string]string{"engine": "engine", "engine_version": "engine_version", "external_id": "arn"},
+	},
+	"aws_dynamodb_table": {
+		Schema:          "Database",
+		NameAttributes:  []string{"name"},
+		AliasAttributes: []string{"arn"},
+		Data:            map[string]string{"external_id": "arn"},
+	},
+	"aws_sqs_queue": {
+		Schema:          "Queue",
+		NameAttributes:  []string{"name"},
+		AliasAttributes: []string{"arn", "url"},
+		Data:            map[string]string{"external_id": "arn"},
+	},
+	"aws_sns_topic": {
+		Schema:          "Queue",
+		NameAttributes:  []string{"name"},
+		AliasAttributes: []string{"arn"},
+		Data:            map[string]string{"external_id": "arn"},
+	},
+	"aws_s3_bucket": {
+		Schema:          "Object Storage",
+		NameAttributes:  []string{"bucket"},
+		AliasAttributes: []string{"arn"},
+		Data:            map[string]string{"external_id": "arn", "region": "region"},
+	},
+	"aws_eks_cluster": {
+		Schema:          "Cluster",
+		NameAttributes:  []string{"name"},
+		AliasAttributes: []string{"arn"},
+		Data:            map[string]string{"external_id": "arn", "version": "version"},
+	},
+	"google_sql_database_instance": {
+		Schema:          "Database",
+		NameAttributes:  []string{"name"},
+		AliasAttributes: []string{"self_link"},
+		Data:            map[string]string{"engine_version": "database_version", "external_id": "self_link", "region": "region"},
+	},
+	"google_pubsub_topic": {
+		Schema:          "Queue",
+		NameAttributes:  []string{"name"},
+		AliasAttributes: []string{"id"},
+		Data:            map[string]string{"external_id": "id"},
+	},
+	"google_storage_bucket": {
+		Schema:          "Object Storage",
+		NameAttributes:  []string{"name"},
+		AliasAttributes: []string{"self_link"},
+		Data:            map[string]string{"external_id": "self_link", "region": "location"},
+	},
+	"google_container_cluster": {
+		Schema:          "Cluster",
+		NameAttributes:  []string{"name"},
+		AliasAttributes: []string{"self_link"},
+		Data:            map[string]string{"external_id": "self_link", "region": "location"},
+	},
+}
+
+var terraformProviderName = regexp.MustCompile(`provider\["(?:[^"]*/)?([^"/]+)"\]`)
+
+type TerraformImportAction string
+
+const (
+	TerraformImportActionCreate TerraformImportAction = "create"
+	TerraformImportActionUpdate TerraformImportAction = "update"
+)
+
+type TerraformImportChange struct {
+	Action     TerraformImportAction
+	Address    string // Terraform resource address, e.g. 'module.db.aws_db_instance.main[0]'
+	Id         string // Existing infrastructure resource id for updates
+	Input      InfraInput
+	Aliases    []string
+	NewAliases []string // Aliases not yet on the existing resource
+}
+
+func (c TerraformImportChange) String() string {
+	return fmt.Sprintf("%s %s '%s' from %s", c.Action, c.Input.Schema, c.Input.Data["name"], c.Address)
+}
+
+type TerraformImportResult struct {
+	Changes []TerraformImportChange
+	Skipped []string // Addresses of resources without a mapping or alias
+	Applied []TerraformImportChange
+	Errors  []error
+}
+
+// Err joins all the errors encountered while importing
+func (r *TerraformImportResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	var messages []string
+	for _, err := range r.Errors {
+		messages = append(messages, err.Error())
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+type TerraformImporter struct {
+	Client       *Client
+	Mappings     map[string]TerraformResourceMapping // Keyed by terraform resource type
+	OwnerTagKeys []string                            // Tag (AWS) or label (GCP) keys holding the owning team alias
+	DryRun       bool
+
+	resolver *Resolver
+}
+
+func NewTerraformImporter(client *Client, dryRun bool) *TerraformImporter {
+	mappings := map[string]TerraformResourceMapping{}
+	for key, value := range DefaultTerraformResourceMappings {
+		mappings[key] = value
+	}
+	return &TerraformImporter{
+		Client:       client,
+		Mappings:     mappings,
+		OwnerTagKeys: []string{"owner", "team"},
+		DryRun:       dryRun,
+	}
+}
+
+func ReadTerraformState(path string) (*TerraformState, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseTerraformState(file)
+}
+
+func ParseTerraformState(reader io.Reader) (*TerraformState, error) {
+	var output TerraformState
+	if err := json.NewDecoder(reader).Decode(&output); err != nil {
+		return nil, err
+	}
+	if output.Version != 4 {
+		return nil, fmt.Errorf("unsupported terraform state version '%d' - expected 4", output.Version)
+	}
+	return &output, nil
+}
+
+// Address returns the terraform address of the instance, e.g. 'module.db.aws_db_instance.main["primary"]'
+func (r *TerraformStateResource) Address(instance TerraformStateInstance) string {
+	address := fmt.Sprintf("%s.%s", r.Type, r.Name)
+	if r.Mode == "data" {
+		address = "data." + address
+	}
+	if r.Module != "" {
+		address = r.Module + "." + address
+	}
+	switch key := instance.IndexKey.(type) {
+	case string:
+		address += fmt.Sprintf("[%q]", key)
+	case float64:
+		address += fmt.Sprintf("[%d]", int(key))
+	}
+	return address
+}
+
+func terraformAttribute(attributes map[string]any, key string) string {
+	switch value := attributes[key].(type) {
+	case string:
+		return value
+	case float64, bool:
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+// ownerAlias returns the value of the first owner tag or label found in the attributes
+func (i *TerraformImporter) ownerAlias(attributes map[string]any) string {
+	for _, field := range []string{"tags", "labels"} {
+		tags, ok := attributes[field].(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, key := range i.OwnerTagKeys {
+			for tagKey, tagValue := range tags {
+				alias, ok := tagValue.(string)
+				if !ok || alias == "" || !strings.EqualFold(tagKey, key) {
+					continue
+				}
+				return alias
+			}
+		}
+	}
+	return ""
+}
+
+func (i *TerraformImporter) ownerFor(attributes map[string]any) (*ID, error) {
+	alias := i.ownerAlias(attributes)
+	if alias == "" {
+		return nil, nil
+	}
+	if team, ok := i.Client.Cacher().TryGetTeam(alias); ok {
+		return &team.Id, nil
+	}
+	id, err := i.resolver.Resolve(ResolverKindTeam, alias)
+	if err != nil {
+		return nil, fmt.Errorf("owner %w", err)
+	}
+	return &id, nil
+}
+
+// Plan maps the managed resources of every state to the infrastructure resource creates and updates needed
+func (i *TerraformImporter) Plan(states ...*TerraformState) (*TerraformImportResult, error) {
+	existing, err := i.Client.ListInfrastructure(nil)
+	if err != nil {
+		return nil, err
+	}
+	byAlias := map[string]InfrastructureResource{}
+	for _, resource := range existing.Nodes {
+		for _, alias := range resource.Aliases {
+			byAlias[alias] = resource
+		}
+	}
+
+	// Queue every owner so they are looked up together when the first one is needed
+	if i.resolver == nil {
+		i.resolver = i.Client.NewResolver()
+	}
+	for _, state := range states {
+		for _, resource := range state.Resources {
+			for _, instance := range resource.Instances {
+				if alias := i.ownerAlias(instance.Attributes); alias != "" {
+					if _, ok := i.Client.Cacher().TryGetTeam(alias); !ok {
+						i.resolver.Want(ResolverKindTeam, alias)
+					}
+				}
+			}
+		}
+	}
+
+	result := &TerraformImportResult{}
+	for _, state := range states {
+		for _, resource := range state.Resources {
+			mapping, ok := i.Mappings[resource.Type]
+			for _, instance := range resource.Instances {
+				address := resource.Address(instance)
+				if !ok || resource.Mode != "managed" {
+					result.Skipped = append(result.Skipped, address)
+					continue
+				}
+				change, err := i.plan(address, resource, mapping, instance.Attributes, byAlias)
+				if err != nil {
+					result.Errors = append(result.Errors, fmt.Errorf("%s: %w", address, err))
+					continue
+				}
+				if change == nil {
+					result.Skipped = append(result.Skipped, address)
+					continue
+				}
+				result.Changes = append(result.Changes, *change)
+			}
+		}
+	}
+	sort.SliceStable(result.Changes, func(a, b int) bool { return result.Changes[a].Address < result.Changes[b].Address })
+	return result, nil
+}
+
+func (i *TerraformImporter) plan(address string, resource TerraformStateResource, mapping TerraformResourceMapping, attributes map[string]any, byAlias map[string]InfrastructureResource) (*TerraformImportChange, error) {
+	var aliases []string
+	for _, key := range mapping.AliasAttributes {
+		if value := terraformAttribute(attributes, key); value != "" {
+			aliases = append(aliases, value)
+		}
+	}
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+	name := ""
+	for _, key := range mapping.NameAttributes {
+		if name = terraformAttribute(attributes, key); name != "" {
+			break
+		}
+	}
+	if name == "" {
+		name = resource.Name
+	}
+	data := map[string]any{"name": name}
+	for field, key := range mapping.Data {
+		if value, ok := attributes[key]; ok && value != nil && value != "" {
+			data[field] = value
+		}
+	}
+	owner, err := i.ownerFor(attributes)
+	if err != nil {
+		return nil, err
+	}
+	provider := &InfraProviderInput{Type: resource.Type}
+	if match := terraformProviderName.FindStringSubmatch(resource.Provider); match != nil {
+		provider.Name = match[1]
+	}
+	change := &TerraformImportChange{
+		Action:  TerraformImportActionCreate,
+		Address: address,
+		Aliases: aliases,
+		Input: InfraInput{
+			Schema:   mapping.Schema,
+			Owner:    owner,
+			Provider: provider,
+			Data:     data,
+		},
+	}
+	if _, ok := i.Client.Cacher().TryGetInfrastructureSchema(mapping.Schema); ok {
+		if err := i.Client.Cacher().ValidateInfraInput(&change.Input, true); err != nil {
+			return nil, err
+		}
+	}
+	current, ok := byAlias[aliases[0]]
+	if !ok {
+		change.NewAliases = aliases
+		return change, nil
+	}
+	change.Action = TerraformImportActionUpdate
+	change.Id = current.Id
+	for _, alias := range aliases {
+		if !slices.Contains(current.Aliases, alias) {
+			change.NewAliases = append(change.NewAliases, alias)
+		}
+	}
+	return change, nil
+}
+
+// Import plans the import and, unless DryRun is set, creates or updates each infrastructure resource and adds its aliases
+func (i *TerraformImporter) Import(states ...*TerraformState) (*TerraformImportResult, error) {
+	result, err := i.Plan(states...)
+	if err != nil {
+		return nil, err
+	}
+	if i.DryRun {
+		return result, result.Err()
+	}
+	for _, change := range result.Changes {
+		var resource *InfrastructureResource
+		if change.Action == TerraformImportActionUpdate {
+			resource, err = i.Client.UpdateInfrastructure(change.Id, change.Input)
+		} else {
+			resource, err = i.Client.CreateInfrastructure(change.Input)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %w", change, err))
+			continue
+		}
+		if len(change.NewAliases) > 0 {
+			if _, err := i.Client.CreateAliases(resource.ResourceId(), change.NewAliases); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("%s: %w", change, err))
+				continue
+			}
+		}
+		result.Applied = append(result.Applied, change)
+	}
+	return result, result.Err()
+}
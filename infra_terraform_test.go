@@ -0,0 +1,123 @@
+package opslevel_test
+
+import (
+	"strings"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+const testTerraformState = `{
+  "version": 4,
+  "terraform_version": "1.5.7",
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_db_instance",
+      "name": "main",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "index_key": 0,
+          "attributes": {
+            "identifier": "orders-db",
+            "arn": "arn:aws:rds:us-east-1:XXXXXXXXXX:db:orders-db",
+            "engine": "postgres",
+            "engine_version": "15.4",
+            "availability_zone": "us-east-1a",
+            "tags": {"Owner": "platform"}
+          }
+        }
+      ]
+    },
+    {
+      "module": "module.network",
+      "mode": "managed",
+      "type": "aws_sqs_queue",
+      "name": "events",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {
+          "index_key": "primary",
+          "attributes": {
+            "name": "events",
+            "arn": "arn:aws:ec2:ca-central-1:XXXXXXXXXX:vpc/vpc-XXXXXXXXXX",
+            "url": "https://sqs.us-east-1.amazonaws.com/XXXXXXXXXX/events"
+          }
+        }
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "aws_s3_bucket",
+      "name": "logs",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"attributes": {"bucket": "logs", "arn": "arn:aws:s3:::logs"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "aws_iam_role",
+      "name": "ci",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"attributes": {"name": "ci", "arn": "arn:aws:iam::XXXXXXXXXX:role/ci"}}]
+    }
+  ]
+}`
+
+func TestParseTerraformState(t *testing.T) {
+	// Act
+	state, err := ol.ParseTerraformState(strings.NewReader(testTerraformState))
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 4, len(state.Resources))
+	autopilot.Equals(t, `aws_db_instance.main[0]`, state.Resources[0].Address(state.Resources[0].Instances[0]))
+	autopilot.Equals(t, `module.network.aws_sqs_queue.events["primary"]`, state.Resources[1].Address(state.Resources[1].Instances[0]))
+	autopilot.Equals(t, `data.aws_s3_bucket.logs`, state.Resources[2].Address(state.Resources[2].Instances[0]))
+}
+
+func TestParseTerraformStateUnsupportedVersion(t *testing.T) {
+	// Act
+	_, err := ol.ParseTerraformState(strings.NewReader(`{"version": 3, "modules": []}`))
+	// Assert
+	autopilot.Equals(t, "unsupported terraform state version '3' - expected 4", err.Error())
+}
+
+func TestTerraformImporterPlan(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query IntegrationList($after:String!$all:Boolean!$first:Int!){account{infrastructureResources(after: $after, first: $first){nodes{id,aliases,name,type @include(if: $all),providerResourceType @include(if: $all),providerData @include(if: $all){accountName,externalUrl,providerName},owner @include(if: $all){... on Team{teamAlias:alias,id}},ownerLocked @include(if: $all),data @include(if: $all),rawData @include(if: $all)},{{ template "pagination_request" }}}}}"`,
+		`{ "after": "", "all": true, "first": 100 }`,
+		`{ "data": { "account": { "infrastructureResources": { "nodes": [ {{ template "infra_1" }}, {{ template "infra_2" }} ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	client := BestTestClient(t, "infra/terraform_plan", testRequest)
+	account := ol.NewAccountRegistry().Register("prod", client)
+	account.Cache.Teams["platform"] = ol.Team{TeamId: ol.TeamId{Alias: "platform", Id: id4}}
+	state, err := ol.ParseTerraformState(strings.NewReader(testTerraformState))
+	autopilot.Ok(t, err)
+	importer := ol.NewTerraformImporter(client, true)
+	// Act
+	result, err := importer.Import(state)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []string{"data.aws_s3_bucket.logs", "aws_iam_role.ci"}, result.Skipped)
+	autopilot.Equals(t, 2, len(result.Changes))
+	autopilot.Equals(t, 0, len(result.Applied))
+
+	create := result.Changes[0]
+	autopilot.Equals(t, ol.TerraformImportActionCreate, create.Action)
+	autopilot.Equals(t, "Database", create.Input.Schema)
+	autopilot.Equals(t, &id4, create.Input.Owner)
+	autopilot.Equals(t, "aws", create.Input.Provider.Name)
+	autopilot.Equals(t, "aws_db_instance", create.Input.Provider.Type)
+	autopilot.Equals(t, "orders-db", create.Input.Data["name"])
+	autopilot.Equals(t, "us-east-1a", create.Input.Data["availability_zone"])
+	autopilot.Equals(t, []string{"arn:aws:rds:us-east-1:XXXXXXXXXX:db:orders-db"}, create.NewAliases)
+	autopilot.Equals(t, "create Database 'orders-db' from aws_db_instance.main[0]", create.String())
+
+	update := result.Changes[1]
+	autopilot.Equals(t, ol.TerraformImportActionUpdate, update.Action)
+	autopilot.Equals(t, string(id2), update.Id)
+	autopilot.Equals(t, "Queue", update.Input.Schema)
+	autopilot.Equals(t, []string{"https://sqs.us-east-1.amazonaws.com/XXXXXXXXXX/events"}, update.NewAliases)
+}
@@ -8,6 +8,20 @@ import (
 	"github.com/gosimple/slug"
 )
 
+// Values of IntegrationId.Type for the integration kinds
+const (
+	IntegrationTypeAWS       = "aws"
+	IntegrationTypeNewRelic  = "new_relic"
+	IntegrationTypeDatadog   = "datadog"
+	IntegrationTypePagerDuty = "pagerduty"
+	IntegrationTypeOpsgenie  = "opsgenie"
+	IntegrationTypeGitHub    = "github"
+	IntegrationTypeGitLab    = "gitlab"
+	IntegrationTypeDeploy    = "deploy"
+	IntegrationTypeGeneric   = "generic" // also used by kubernetes
+	IntegrationTypePayload   = "payload"
+)
+
 type IntegrationId struct {
 	Id   ID     `json:"id"`
 	Name string `json:"name"`
@@ -24,6 +38,39 @@ type Integration struct {
 	NewRelicIntegrationFragment `graphql:"... on NewRelicIntegration"`
 }
 
+// The other integration kinds are only requested by the calls that return them
+// so ListIntegrations and GetIntegration keep working regardless of which kinds an account has
+
+type DatadogIntegration struct {
+	Integration
+	DatadogIntegrationFragment `graphql:"... on DatadogIntegration"`
+}
+
+type PagerDutyIntegration struct {
+	Integration
+	PagerDutyIntegrationFragment `graphql:"... on PagerdutyIntegration"`
+}
+
+type OpsgenieIntegration struct {
+	Integration
+	OpsgenieIntegrationFragment `graphql:"... on OpsgenieIntegration"`
+}
+
+type GitHubIntegration struct {
+	Integration
+	GitHubIntegrationFragment `graphql:"... on GithubIntegration"`
+}
+
+type GitLabIntegration struct {
+	Integration
+	GitLabIntegrationFragment `graphql:"... on GitlabIntegration"`
+}
+
+type EventIntegration struct {
+	Integration
+	EventIntegrationFragment `graphql:"... on EventIntegration"`
+}
+
 type AWSIntegrationFragment struct {
 	IAMRole              string   `graphql:"iamRole"`
 	ExternalID           string   `graphql:"externalId"`
@@ -36,6 +83,32 @@ type NewRelicIntegrationFragment struct {
 	AccountKey string `graphql:"accountKey"`
 }
 
+type DatadogIntegrationFragment struct {
+	SiteURL string `graphql:"siteUrl"`
+}
+
+type PagerDutyIntegrationFragment struct {
+	IsReadOnly bool `graphql:"isReadOnly"`
+}
+
+type OpsgenieIntegrationFragment struct {
+	Region string `graphql:"region"`
+}
+
+type GitHubIntegrationFragment struct {
+	Organization string `graphql:"organization"`
+}
+
+type GitLabIntegrationFragment struct {
+	InstanceURL string `graphql:"instanceUrl"`
+}
+
+// EventIntegrationFragment is shared by every integration that receives events on a webhook,
+// e.g. deploy, generic (used by kubernetes), api doc and check integrations
+type EventIntegrationFragment struct {
+	WebhookURL string `graphql:"webhookUrl"`
+}
+
 type IntegrationConnection struct {
 	Nodes      []Integration
 	PageInfo   PageInfo
@@ -56,8 +129,45 @@ type NewRelicIntegrationInput struct {
 	AccountKey *string `json:"accountKey,omitempty"`
 }
 
+type OpsgenieIntegrationInput struct {
+	Name   *string `json:"name,omitempty"`
+	ApiKey *string `json:"apiKey,omitempty"`
+	Region *string `json:"region,omitempty"`
+}
+
+// EventIntegrationInput creates an integration that receives events on a webhook
+type EventIntegrationInput struct {
+	Name *string              `json:"name,omitempty"`
+	Type EventIntegrationEnum `json:"type"`
+}
+
+type EventIntegrationUpdateInput struct {
+	Id   ID     `json:"id"`
+	Name string `json:"name"`
+}
+
 func (s AWSIntegrationInput) GetGraphQLType() string      { return "AwsIntegrationInput" }
 func (s NewRelicIntegrationInput) GetGraphQLType() string { return "NewRelicIntegrationInput" }
+func (s OpsgenieIntegrationInput) GetGraphQLType() string { return "OpsgenieIntegrationInput" }
+
+// IsEventIntegration is true for integrations that receive events on a webhook
+func (self *EventIntegration) IsEventIntegration() bool {
+	return self.WebhookURL != ""
+}
+
+// OfType returns the integrations in the connection with one of 'types', e.g. IntegrationTypeDeploy
+func (c *IntegrationConnection) OfType(types ...string) []Integration {
+	var output []Integration
+	for _, integration := range c.Nodes {
+		for _, t := range types {
+			if integration.Type == t {
+				output = append(output, integration)
+				break
+			}
+		}
+	}
+	return output
+}
 
 func (self *IntegrationId) Alias() string {
 	return fmt.Sprintf("%s-%s", slug.Make(self.Type), slug.Make(self.Name))
@@ -98,6 +208,37 @@ func (client *Client) CreateIntegrationNewRelic(input NewRelicIntegrationInput)
 	return m.Payload.Integration, HandleErrors(err, m.Payload.Errors)
 }
 
+func (client *Client) CreateIntegrationOpsgenie(input OpsgenieIntegrationInput) (*OpsgenieIntegration, error) {
+	var m struct {
+		Payload struct {
+			Integration *OpsgenieIntegration
+			Errors      []OpsLevelErrors
+		} `graphql:"opsgenieIntegrationCreate(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("OpsgenieIntegrationCreate"))
+	return m.Payload.Integration, HandleErrors(err, m.Payload.Errors)
+}
+
+// CreateEventIntegration creates a webhook based integration.
+// Datadog, PagerDuty, GitHub and GitLab integrations are authorized in the UI so they cannot be created through the API,
+// Kubernetes clusters report through a generic event integration - every integration can be deleted with DeleteIntegration.
+func (client *Client) CreateEventIntegration(input EventIntegrationInput) (*EventIntegration, error) {
+	var m struct {
+		Payload struct {
+			Integration *EventIntegration
+			Errors      []OpsLevelErrors
+		} `graphql:"eventIntegrationCreate(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("EventIntegrationCreate"))
+	return m.Payload.Integration, HandleErrors(err, m.Payload.Errors)
+}
+
 //#endregion
 
 //#region Retrieve
@@ -118,6 +259,58 @@ func (client *Client) GetIntegration(id ID) (*Integration, error) {
 	return &q.Account.Integration, HandleErrors(err, nil)
 }
 
+func getIntegrationAs[T any](client *Client, id ID, name string) (*T, error) {
+	var q struct {
+		Account struct {
+			Integration *T `graphql:"integration(id: $id)"`
+		}
+	}
+	v := PayloadVariables{
+		"id": id,
+	}
+	err := client.Query(&q, v, WithName(name))
+	if err == nil && q.Account.Integration == nil {
+		err = fmt.Errorf("Integration with ID '%s' not found!", id)
+	}
+	return q.Account.Integration, HandleErrors(err, nil)
+}
+
+func (client *Client) GetDatadogIntegration(id ID) (*DatadogIntegration, error) {
+	return getIntegrationAs[DatadogIntegration](client, id, "DatadogIntegrationGet")
+}
+
+func (client *Client) GetPagerDutyIntegration(id ID) (*PagerDutyIntegration, error) {
+	return getIntegrationAs[PagerDutyIntegration](client, id, "PagerDutyIntegrationGet")
+}
+
+func (client *Client) GetOpsgenieIntegration(id ID) (*OpsgenieIntegration, error) {
+	return getIntegrationAs[OpsgenieIntegration](client, id, "OpsgenieIntegrationGet")
+}
+
+func (client *Client) GetGitHubIntegration(id ID) (*GitHubIntegration, error) {
+	return getIntegrationAs[GitHubIntegration](client, id, "GitHubIntegrationGet")
+}
+
+func (client *Client) GetGitLabIntegration(id ID) (*GitLabIntegration, error) {
+	return getIntegrationAs[GitLabIntegration](client, id, "GitLabIntegrationGet")
+}
+
+func (client *Client) GetEventIntegration(id ID) (*EventIntegration, error) {
+	return getIntegrationAs[EventIntegration](client, id, "EventIntegrationGet")
+}
+
+// GetIntegrationWebhookURL returns the url an event integration receives events on
+func (client *Client) GetIntegrationWebhookURL(id ID) (string, error) {
+	integration, err := client.GetEventIntegration(id)
+	if err != nil {
+		return "", err
+	}
+	if !integration.IsEventIntegration() {
+		return "", fmt.Errorf("Integration '%s' of type '%s' does not have a webhook url", id, integration.Type)
+	}
+	return integration.WebhookURL, nil
+}
+
 func (client *Client) ListIntegrations(variables *PayloadVariables) (IntegrationConnection, error) {
 	var q struct {
 		Account struct {
@@ -177,6 +370,35 @@ func (client *Client) UpdateIntegrationNewRelic(identifier string, input NewReli
 	return m.Payload.Integration, HandleErrors(err, m.Payload.Errors)
 }
 
+func (client *Client) UpdateIntegrationOpsgenie(identifier string, input OpsgenieIntegrationInput) (*OpsgenieIntegration, error) {
+	var m struct {
+		Payload struct {
+			Integration *OpsgenieIntegration
+			Errors      []OpsLevelErrors
+		} `graphql:"opsgenieIntegrationUpdate(input: $input resource: $resource)"`
+	}
+	v := PayloadVariables{
+		"resource": *NewIdentifier(identifier),
+		"input":    input,
+	}
+	err := client.Mutate(&m, v, WithName("OpsgenieIntegrationUpdate"))
+	return m.Payload.Integration, HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) UpdateEventIntegration(input EventIntegrationUpdateInput) (*EventIntegration, error) {
+	var m struct {
+		Payload struct {
+			Integration *EventIntegration
+			Errors      []OpsLevelErrors
+		} `graphql:"eventIntegrationUpdate(input: $input)"`
+	}
+	v := PayloadVariables{
+		"input": input,
+	}
+	err := client.Mutate(&m, v, WithName("EventIntegrationUpdate"))
+	return m.Payload.Integration, HandleErrors(err, m.Payload.Errors)
+}
+
 //#endregion
 
 //#region Delete
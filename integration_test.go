@@ -1,6 +1,7 @@
 package opslevel_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/opslevel/opslevel-go/v2023"
@@ -75,6 +76,83 @@ func TestCreateNewRelicIntegration(t *testing.T) {
 	autopilot.Equals(t, "New Relic - XXXX", result.Name)
 }
 
+func TestCreateEventIntegration(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation EventIntegrationCreate($input:EventIntegrationInput!){eventIntegrationCreate(input: $input){integration{id,name,type,createdAt,installedAt,... on AwsIntegration{iamRole,externalId,awsTagsOverrideOwnership,ownershipTagKeys},... on NewRelicIntegration{baseUrl,accountKey},... on EventIntegration{webhookUrl}},errors{message,path}}}"`,
+		`{"input": { "name": "Kubernetes", "type": "generic" }}`,
+		`{"data": {
+      "eventIntegrationCreate": {
+        "integration": {
+          {{ template "kubernetes_integration_response" }},
+          "webhookUrl": "https://app.opslevel.com/integrations/generic/XXXX"
+        },
+        "errors": []
+      }}}`,
+	)
+	client := BestTestClient(t, "integration/create_event", testRequest)
+	// Act
+	result, err := client.CreateEventIntegration(opslevel.EventIntegrationInput{
+		Name: opslevel.NewString("Kubernetes"),
+		Type: opslevel.EventIntegrationEnumGeneric,
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, opslevel.IntegrationTypeGeneric, result.Type)
+	autopilot.Equals(t, true, result.IsEventIntegration())
+	autopilot.Equals(t, "https://app.opslevel.com/integrations/generic/XXXX", result.WebhookURL)
+}
+
+func TestCreateOpsgenieIntegration(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation OpsgenieIntegrationCreate($input:OpsgenieIntegrationInput!){opsgenieIntegrationCreate(input: $input){integration{id,name,type,createdAt,installedAt,... on AwsIntegration{iamRole,externalId,awsTagsOverrideOwnership,ownershipTagKeys},... on NewRelicIntegration{baseUrl,accountKey},... on OpsgenieIntegration{region}},errors{message,path}}}"`,
+		`{"input": { "name": "Opsgenie", "apiKey": "123456789", "region": "EU" }}`,
+		`{"data": {
+      "opsgenieIntegrationCreate": {
+        "integration": {
+          {{ template "id1" }},
+          "name": "Opsgenie",
+          "type": "opsgenie",
+          "region": "EU"
+        },
+        "errors": []
+      }}}`,
+	)
+	client := BestTestClient(t, "integration/create_opsgenie", testRequest)
+	// Act
+	result, err := client.CreateIntegrationOpsgenie(opslevel.OpsgenieIntegrationInput{
+		Name:   opslevel.NewString("Opsgenie"),
+		ApiKey: opslevel.NewString("123456789"),
+		Region: opslevel.NewString("EU"),
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, opslevel.IntegrationTypeOpsgenie, result.Type)
+	autopilot.Equals(t, "EU", result.Region)
+}
+
+func TestUpdateOpsgenieIntegration(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation OpsgenieIntegrationUpdate($input:OpsgenieIntegrationInput!$resource:IdentifierInput!){opsgenieIntegrationUpdate(input: $input resource: $resource){integration{id,name,type,createdAt,installedAt,... on AwsIntegration{iamRole,externalId,awsTagsOverrideOwnership,ownershipTagKeys},... on NewRelicIntegration{baseUrl,accountKey},... on OpsgenieIntegration{region}},errors{message,path}}}"`,
+		`{"resource": { {{ template "id1" }} }, "input": { "region": "US" }}`,
+		`{"data": {
+      "opsgenieIntegrationUpdate": {
+        "integration": { {{ template "id1" }}, "name": "Opsgenie", "type": "opsgenie", "region": "US" },
+        "errors": []
+      }}}`,
+	)
+	client := BestTestClient(t, "integration/update_opsgenie", testRequest)
+	// Act
+	result, err := client.UpdateIntegrationOpsgenie(string(id1), opslevel.OpsgenieIntegrationInput{
+		Region: opslevel.NewString("US"),
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "US", result.Region)
+}
+
 func TestGetIntegration(t *testing.T) {
 	// Arrange
 	testRequest := NewTestRequest(
@@ -98,6 +176,36 @@ func TestGetIntegration(t *testing.T) {
 	autopilot.Equals(t, "Deploy", result.Name)
 }
 
+func TestGetPagerDutyIntegration(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query PagerDutyIntegrationGet($id:ID!){account{integration(id: $id){id,name,type,createdAt,installedAt,... on AwsIntegration{iamRole,externalId,awsTagsOverrideOwnership,ownershipTagKeys},... on NewRelicIntegration{baseUrl,accountKey},... on PagerdutyIntegration{isReadOnly}}}}"`,
+		`{ {{ template "id1" }} }`,
+		`{"data": { "account": { "integration": { {{ template "id1" }}, "name": "PagerDuty", "type": "pagerduty", "isReadOnly": true }}}}`,
+	)
+	client := BestTestClient(t, "integration/get_pagerduty", testRequest)
+	// Act
+	result, err := client.GetPagerDutyIntegration(id1)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, opslevel.IntegrationTypePagerDuty, result.Type)
+	autopilot.Equals(t, true, result.IsReadOnly)
+}
+
+func TestGetMissingPagerDutyIntegration(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query PagerDutyIntegrationGet($id:ID!){account{integration(id: $id){id,name,type,createdAt,installedAt,... on AwsIntegration{iamRole,externalId,awsTagsOverrideOwnership,ownershipTagKeys},... on NewRelicIntegration{baseUrl,accountKey},... on PagerdutyIntegration{isReadOnly}}}}"`,
+		`{ {{ template "id2" }} }`,
+		`{"data": { "account": { "integration": null }}}`,
+	)
+	client := BestTestClient(t, "integration/get_missing_pagerduty", testRequest)
+	// Act
+	_, err := client.GetPagerDutyIntegration(id2)
+	// Assert
+	autopilot.Assert(t, err != nil, "This test should throw an error.")
+}
+
 func TestGetMissingIntegraion(t *testing.T) {
 	// Arrange
 	testRequest := NewTestRequest(
@@ -112,6 +220,28 @@ func TestGetMissingIntegraion(t *testing.T) {
 	autopilot.Assert(t, err != nil, "This test should throw an error.")
 }
 
+func TestGetIntegrationWebhookURL(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"query EventIntegrationGet($id:ID!){account{integration(id: $id){id,name,type,createdAt,installedAt,... on AwsIntegration{iamRole,externalId,awsTagsOverrideOwnership,ownershipTagKeys},... on NewRelicIntegration{baseUrl,accountKey},... on EventIntegration{webhookUrl}}}}"`,
+		`{ {{ template "id1" }} }`,
+		`{"data": { "account": { "integration": { {{ template "id1" }}, "name": "Deploy", "type": "deploy", "webhookUrl": "https://app.opslevel.com/integrations/deploy/XXXX" }}}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"query EventIntegrationGet($id:ID!){account{integration(id: $id){id,name,type,createdAt,installedAt,... on AwsIntegration{iamRole,externalId,awsTagsOverrideOwnership,ownershipTagKeys},... on NewRelicIntegration{baseUrl,accountKey},... on EventIntegration{webhookUrl}}}}"`,
+		`{ {{ template "id2" }} }`,
+		`{"data": { "account": { "integration": { {{ template "id2" }}, "name": "PagerDuty", "type": "pagerduty" }}}}`,
+	)
+	client := BestTestClient(t, "integration/get_webhook_url", testRequestOne, testRequestTwo)
+	// Act
+	url, err := client.GetIntegrationWebhookURL(id1)
+	_, missingErr := client.GetIntegrationWebhookURL(id2)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "https://app.opslevel.com/integrations/deploy/XXXX", url)
+	autopilot.Equals(t, fmt.Sprintf("Integration '%s' of type 'pagerduty' does not have a webhook url", id2), missingErr.Error())
+}
+
 func TestListIntegrations(t *testing.T) {
 	// Arrange
 	testRequestOne := NewTestRequest(
@@ -135,6 +265,7 @@ func TestListIntegrations(t *testing.T) {
 	autopilot.Equals(t, 3, response.TotalCount)
 	autopilot.Equals(t, "Payload", result[1].Name)
 	autopilot.Equals(t, "Kubernetes", result[2].Name)
+	autopilot.Equals(t, 2, len(response.OfType(opslevel.IntegrationTypeDeploy, opslevel.IntegrationTypeGeneric)))
 }
 
 func TestUpdateAWSIntegration(t *testing.T) {
@@ -206,6 +337,25 @@ func TestUpdateNewRelicIntegration(t *testing.T) {
 	autopilot.Equals(t, "https://api-test.newrelic.com/graphql", result.BaseUrl)
 }
 
+func TestUpdateEventIntegration(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation EventIntegrationUpdate($input:EventIntegrationUpdateInput!){eventIntegrationUpdate(input: $input){integration{id,name,type,createdAt,installedAt,... on AwsIntegration{iamRole,externalId,awsTagsOverrideOwnership,ownershipTagKeys},... on NewRelicIntegration{baseUrl,accountKey},... on EventIntegration{webhookUrl}},errors{message,path}}}"`,
+		`{"input": { {{ template "id1" }}, "name": "Deploys" }}`,
+		`{"data": {
+      "eventIntegrationUpdate": {
+        "integration": { {{ template "id1" }}, "name": "Deploys", "type": "deploy", "webhookUrl": "https://app.opslevel.com/integrations/deploy/XXXX" },
+        "errors": []
+      }}}`,
+	)
+	client := BestTestClient(t, "integration/update_event", testRequest)
+	// Act
+	result, err := client.UpdateEventIntegration(opslevel.EventIntegrationUpdateInput{Id: id1, Name: "Deploys"})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "Deploys", result.Name)
+}
+
 func TestDeleteIntegration(t *testing.T) {
 	// Arrange
 	testRequest := NewTestRequest(
@@ -0,0 +1,357 @@
+package opslevel
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hasura/go-graphql-client"
+)
+
+// JournalEntry is a line of the mutation journal
+type JournalEntry struct {
+	Time       time.Time       `json:"time"`
+	Operation  string          `json:"operation"`
+	Variables  json.RawMessage `json:"variables"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	Prior      json.RawMessage `json:"prior,omitempty"`      // State of the entity before an update or delete, when supported
+	PriorError string          `json:"priorError,omitempty"` // Why the prior state could not be recorded
+	Error      string          `json:"error,omitempty"`
+}
+
+// journalService is the state of a service recorded before it is updated or deleted
+type journalService struct {
+	Id          ID
+	Aliases     []string
+	Name        string
+	Description string
+	Framework   string
+	Language    string
+	Product     string
+	Lifecycle   struct{ Alias string }
+	Owner       TeamId
+	Tier        struct{ Alias string }
+}
+
+// journalTeam is the state of a team recorded before it is updated or deleted
+type journalTeam struct {
+	TeamId
+	Name             string
+	Responsibilities string
+	Manager          struct{ Email string }
+	ParentTeam       TeamId
+}
+
+// journalPrior fetches the state of the entity a mutation is about to change, keyed by operation
+var journalPrior = map[string]func(client *Client, input json.RawMessage) (any, error){
+	"ServiceUpdate": journalPriorService,
+	"ServiceDelete": journalPriorService,
+	"TeamUpdate":    journalPriorTeam,
+	"TeamDelete":    journalPriorTeam,
+}
+
+func journalPriorService(client *Client, input json.RawMessage) (any, error) {
+	var identifier IdentifierInput
+	if err := json.Unmarshal(input, &identifier); err != nil {
+		return nil, err
+	}
+	var q struct {
+		Account struct {
+			Service journalService `graphql:"service(id: $id, alias: $alias)"`
+		}
+	}
+	v := PayloadVariables{"id": nilIfEmpty(identifier.Id), "alias": nilIfEmpty(identifier.Alias)}
+	if err := client.Query(&q, v, WithName("JournalServiceGet")); err != nil {
+		return nil, err
+	}
+	return q.Account.Service, nil
+}
+
+func journalPriorTeam(client *Client, input json.RawMessage) (any, error) {
+	var identifier IdentifierInput
+	if err := json.Unmarshal(input, &identifier); err != nil {
+		return nil, err
+	}
+	var q struct {
+		Account struct {
+			Team journalTeam `graphql:"team(id: $id, alias: $alias)"`
+		}
+	}
+	v := PayloadVariables{"id": nilIfEmpty(identifier.Id), "alias": nilIfEmpty(identifier.Alias)}
+	if err := client.Query(&q, v, WithName("JournalTeamGet")); err != nil {
+		return nil, err
+	}
+	return q.Account.Team, nil
+}
+
+func nilIfEmpty[T ~string](value T) *T {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+type journal struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+// mutate sends the mutation, recording it with its sensitive variables redacted along with the prior state of the entity it changes
+func (j *journal) mutate(ctx context.Context, client *Client, m interface{}, variables map[string]interface{}, options ...graphql.Option) error {
+	query, err := graphql.ConstructMutation(m, variables, options...)
+	if err != nil {
+		return err
+	}
+	entry := JournalEntry{Time: time.Now().UTC(), Operation: operationName(query)}
+	redacted, err := redactVariables(entry.Operation, variables)
+	if err != nil {
+		return err
+	}
+	if entry.Variables, err = json.Marshal(redacted); err != nil {
+		return err
+	}
+	if prior, ok := journalPrior[entry.Operation]; ok {
+		state, err := journalPriorState(client, prior, variables)
+		if err != nil {
+			entry.PriorError = err.Error()
+		} else {
+			entry.Prior, _ = json.Marshal(state)
+		}
+	}
+
+	mutateErr := client.client.Mutate(ctx, m, variables, options...)
+	var payload any = m
+	if value := reflect.ValueOf(m); value.Kind() == reflect.Pointer && value.Elem().Kind() == reflect.Struct && value.Elem().NumField() == 1 {
+		payload = value.Elem().Field(0).Interface()
+	}
+	if err := HandleErrors(mutateErr, payloadErrors(payload)); err != nil {
+		entry.Error = err.Error()
+	}
+	if entry.Payload, err = json.Marshal(payload); err != nil {
+		return err
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if err := j.encoder.Encode(entry); err != nil {
+		return errors.Join(mutateErr, fmt.Errorf("journal: %w", err))
+	}
+	return mutateErr
+}
+
+func journalPriorState(client *Client, prior func(client *Client, input json.RawMessage) (any, error), variables map[string]interface{}) (any, error) {
+	input, ok := variables["input"]
+	if !ok {
+		return nil, errors.New("mutation has no input variable")
+	}
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	return prior(client, raw)
+}
+
+// payloadErrors returns the Errors field of a mutation payload, nil when it has none
+func payloadErrors(payload any) []OpsLevelErrors {
+	value := reflect.Indirect(reflect.ValueOf(payload))
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	field := value.FieldByName("Errors")
+	if !field.IsValid() {
+		return nil
+	}
+	errs, _ := field.Interface().([]OpsLevelErrors)
+	return errs
+}
+
+// ReadJournal parses the JSON lines written by a client created with SetJournal
+func ReadJournal(reader io.Reader) ([]JournalEntry, error) {
+	var output []JournalEntry
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("journal line %d: %w", line, err)
+		}
+		output = append(output, entry)
+	}
+	return output, scanner.Err()
+}
+
+// journalUndo applies the inverse of a journaled mutation, keyed by operation
+var journalUndo = map[string]func(client *Client, entry JournalEntry) error{
+	"ServiceCreate": func(client *Client, entry JournalEntry) error {
+		var payload struct{ Service ServiceId }
+		if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+			return err
+		}
+		if payload.Service.Id == "" {
+			return nil
+		}
+		return client.DeleteService(ServiceDeleteInput{Id: payload.Service.Id})
+	},
+	"ServiceUpdate": func(client *Client, entry JournalEntry) error {
+		var prior journalService
+		if err := unmarshalPrior(entry, &prior); err != nil {
+			return err
+		}
+		input := ServiceUpdateInput{
+			Id:          prior.Id,
+			Name:        prior.Name,
+			Product:     prior.Product,
+			Description: prior.Description,
+			Language:    prior.Language,
+			Framework:   prior.Framework,
+			Tier:        prior.Tier.Alias,
+			Lifecycle:   prior.Lifecycle.Alias,
+		}
+		if prior.Owner.Id != "" {
+			input.Owner = &IdentifierInput{Id: prior.Owner.Id}
+		}
+		_, err := client.UpdateService(input)
+		return err
+	},
+	"ServiceDelete": func(client *Client, entry JournalEntry) error {
+		var prior journalService
+		if err := unmarshalPrior(entry, &prior); err != nil {
+			return err
+		}
+		input := ServiceCreateInput{
+			Name:        prior.Name,
+			Product:     prior.Product,
+			Description: prior.Description,
+			Language:    prior.Language,
+			Framework:   prior.Framework,
+			Tier:        prior.Tier.Alias,
+			Lifecycle:   prior.Lifecycle.Alias,
+		}
+		if prior.Owner.Id != "" {
+			input.Owner = &IdentifierInput{Id: prior.Owner.Id}
+		}
+		service, err := client.CreateService(input)
+		if err != nil {
+			return err
+		}
+		var aliases []string
+		for _, alias := range prior.Aliases {
+			if !service.HasAlias(alias) {
+				aliases = append(aliases, alias)
+			}
+		}
+		if len(aliases) == 0 {
+			return nil
+		}
+		_, err = client.CreateAliases(service.Id, aliases)
+		return err
+	},
+	"TeamCreate": func(client *Client, entry JournalEntry) error {
+		var payload struct{ Team TeamId }
+		if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+			return err
+		}
+		if payload.Team.Id == "" {
+			return nil
+		}
+		return client.DeleteTeam(payload.Team.Id)
+	},
+	"TeamUpdate": func(client *Client, entry JournalEntry) error {
+		var prior journalTeam
+		if err := unmarshalPrior(entry, &prior); err != nil {
+			return err
+		}
+		input := TeamUpdateInput{
+			Id:               prior.Id,
+			Name:             prior.Name,
+			ManagerEmail:     prior.Manager.Email,
+			Responsibilities: prior.Responsibilities,
+		}
+		if prior.ParentTeam.Id != "" {
+			input.ParentTeam = &IdentifierInput{Id: prior.ParentTeam.Id}
+		}
+		_, err := client.UpdateTeam(input)
+		return err
+	},
+	"TeamDelete": func(client *Client, entry JournalEntry) error {
+		var prior journalTeam
+		if err := unmarshalPrior(entry, &prior); err != nil {
+			return err
+		}
+		input := TeamCreateInput{
+			Name:             prior.Name,
+			ManagerEmail:     prior.Manager.Email,
+			Responsibilities: prior.Responsibilities,
+		}
+		if prior.ParentTeam.Id != "" {
+			input.ParentTeam = &IdentifierInput{Id: prior.ParentTeam.Id}
+		}
+		_, err := client.CreateTeam(input)
+		return err
+	},
+	"TagCreate": func(client *Client, entry JournalEntry) error {
+		var payload struct{ Tag Tag }
+		if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+			return err
+		}
+		if payload.Tag.Id == "" {
+			return nil
+		}
+		return client.DeleteTag(payload.Tag.Id)
+	},
+	"ToolCreate": func(client *Client, entry JournalEntry) error {
+		var payload struct{ Tool Tool }
+		if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+			return err
+		}
+		if payload.Tool.Id == "" {
+			return nil
+		}
+		return client.DeleteTool(payload.Tool.Id)
+	},
+}
+
+func unmarshalPrior(entry JournalEntry, prior any) error {
+	if entry.PriorError != "" {
+		return fmt.Errorf("prior state was not recorded: %s", entry.PriorError)
+	}
+	if len(entry.Prior) == 0 || string(entry.Prior) == "null" {
+		return errors.New("prior state was not recorded")
+	}
+	return json.Unmarshal(entry.Prior, prior)
+}
+
+// Undo applies the inverse of the journal entries, newest first, skipping mutations that failed or returned no ID. Created services
+// and teams are deleted, updated ones are restored to their prior state - fields that were empty are left as is -
+// and deleted ones are recreated with a new ID. Entries that cannot be undone are reported in the returned error.
+func (client *Client) Undo(entries []JournalEntry) error {
+	var messages []string
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.Error != "" {
+			continue
+		}
+		undo, ok := journalUndo[entry.Operation]
+		if !ok {
+			messages = append(messages, fmt.Sprintf("%s at %s: undo is not supported", entry.Operation, entry.Time.Format(time.RFC3339)))
+			continue
+		}
+		if err := undo(client, entry); err != nil {
+			messages = append(messages, fmt.Sprintf("%s at %s: %s", entry.Operation, entry.Time.Format(time.RFC3339), err))
+		}
+	}
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, "\n"))
+	}
+	return nil
+}
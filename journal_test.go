@@ -0,0 +1,142 @@
+package opslevel_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestJournalRecordsMutations(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"mutation TagCreate($input:TagCreateInput!){tagCreate(input: $input){tag{id,key,value},errors{message,path}}}"`,
+		`{"input": { {{ template "id1" }}, "key": "env", "value": "prod" }}`,
+		`{"data": { "tagCreate": { "tag": { {{ template "id2" }}, "key": "env", "value": "prod" }, "errors": [] }}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"query JournalTeamGet($alias:String$id:ID){account{team(id: $id, alias: $alias){alias,id,name,responsibilities,manager{email},parentTeam{alias,id}}}}"`,
+		`{"alias": null, {{ template "id3" }} }`,
+		`{"data": { "account": { "team": { "alias": "platform", {{ template "id3" }}, "name": "Platform", "responsibilities": "Everything", "manager": { "email": "kyle@opslevel.com" }, "parentTeam": null }}}}`,
+	)
+	testRequestThree := NewTestRequest(
+		`"mutation TeamDelete($input:TeamDeleteInput!){teamDelete(input: $input){deletedTeamId,deletedTeamAlias,errors{message,path}}}"`,
+		`{"input": { {{ template "id3" }} }}`,
+		`{"data": { "teamDelete": { "deletedTeamId": "{{ template "id3_string" }}", "deletedTeamAlias": "platform", "errors": [] }}}`,
+	)
+	var buffer bytes.Buffer
+	url := RegisterPaginatedEndpoint(t, "journal/record", testRequestOne, testRequestTwo, testRequestThree)
+	client := ol.NewGQLClient(ol.SetAPIToken("x"), ol.SetMaxRetries(0), ol.SetURL(url), ol.SetJournal(&buffer))
+	// Act
+	_, err := client.CreateTag(ol.TagCreateInput{Id: id1, Key: "env", Value: "prod"})
+	autopilot.Ok(t, err)
+	err = client.DeleteTeam(id3)
+	autopilot.Ok(t, err)
+	entries, err := ol.ReadJournal(&buffer)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, len(entries))
+	autopilot.Equals(t, "TagCreate", entries[0].Operation)
+	autopilot.Equals(t, `{"tag":{"id":"`+string(id2)+`","key":"env","value":"prod"},"Errors":[]}`, string(entries[0].Payload))
+	autopilot.Equals(t, 0, len(entries[0].Prior))
+	autopilot.Equals(t, "TeamDelete", entries[1].Operation)
+	autopilot.Equals(t, `{"input":{"id":"`+string(id3)+`"}}`, string(entries[1].Variables))
+	autopilot.Assert(t, strings.Contains(string(entries[1].Prior), `"Responsibilities":"Everything"`), string(entries[1].Prior))
+}
+
+func TestJournalRecordsPayloadErrors(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation TagCreate($input:TagCreateInput!){tagCreate(input: $input){tag{id,key,value},errors{message,path}}}"`,
+		`{"input": { {{ template "id1" }}, "key": "env", "value": "prod" }}`,
+		`{"data": { "tagCreate": { "tag": null, "errors": [{ "message": "tag already exists", "path": ["key"] }] }}}`,
+	)
+	var buffer bytes.Buffer
+	url := RegisterPaginatedEndpoint(t, "journal/payload_errors", testRequest)
+	client := ol.NewGQLClient(ol.SetAPIToken("x"), ol.SetMaxRetries(0), ol.SetURL(url), ol.SetJournal(&buffer))
+	// Act
+	_, createErr := client.CreateTag(ol.TagCreateInput{Id: id1, Key: "env", Value: "prod"})
+	entries, err := ol.ReadJournal(&buffer)
+	// Assert
+	autopilot.Assert(t, createErr != nil, "This test should throw an error.")
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(entries))
+	autopilot.Assert(t, strings.Contains(entries[0].Error, "tag already exists"), entries[0].Error)
+	autopilot.Ok(t, client.Undo(entries))
+}
+
+func TestJournalRedactsSecrets(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation SecretsVaultsSecretCreate($alias:String!$input:SecretInput!){secretsVaultsSecretCreate(alias: $alias, input: $input){secret{alias,id,owner{alias,id},timestamps{createdAt,updatedAt}},errors{message,path}}}"`,
+		`{{ template "secret_create_vars" }}`,
+		`{{ template "secret_create_response" }}`,
+	)
+	var buffer bytes.Buffer
+	url := RegisterPaginatedEndpoint(t, "journal/redact", testRequest)
+	client := ol.NewGQLClient(ol.SetAPIToken("x"), ol.SetMaxRetries(0), ol.SetURL(url), ol.SetJournal(&buffer))
+	// Act
+	_, err := client.CreateSecret("alias1", ol.SecretInput{Owner: ol.IdentifierInput{Id: id2}, Value: "my-secret"})
+	autopilot.Ok(t, err)
+	entries, err := ol.ReadJournal(&buffer)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, `{"alias":"alias1","input":{"owner":{"id":"`+string(id2)+`"},"value":"[REDACTED]"}}`, string(entries[0].Variables))
+	autopilot.Assert(t, !strings.Contains(buffer.String(), "my-secret"), buffer.String())
+}
+
+func TestJournalRecordsPriorErrors(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"query JournalTeamGet($alias:String$id:ID){account{team(id: $id, alias: $alias){alias,id,name,responsibilities,manager{email},parentTeam{alias,id}}}}"`,
+		`{"alias": null, {{ template "id3" }} }`,
+		`{"errors": [{ "message": "rate limited" }]}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"mutation TeamDelete($input:TeamDeleteInput!){teamDelete(input: $input){deletedTeamId,deletedTeamAlias,errors{message,path}}}"`,
+		`{"input": { {{ template "id3" }} }}`,
+		`{"data": { "teamDelete": { "deletedTeamId": "{{ template "id3_string" }}", "deletedTeamAlias": "platform", "errors": [] }}}`,
+	)
+	var buffer bytes.Buffer
+	url := RegisterPaginatedEndpoint(t, "journal/prior_errors", testRequestOne, testRequestTwo)
+	client := ol.NewGQLClient(ol.SetAPIToken("x"), ol.SetMaxRetries(0), ol.SetURL(url), ol.SetJournal(&buffer))
+	// Act
+	autopilot.Ok(t, client.DeleteTeam(id3))
+	entries, err := ol.ReadJournal(&buffer)
+	undoErr := client.Undo(entries)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Assert(t, strings.Contains(entries[0].PriorError, "rate limited"), entries[0].PriorError)
+	autopilot.Equals(t, 0, len(entries[0].Prior))
+	autopilot.Assert(t, undoErr != nil && strings.Contains(undoErr.Error(), "prior state was not recorded: "), fmt.Sprint(undoErr))
+}
+
+func TestJournalUndo(t *testing.T) {
+	// Arrange
+	journal := `{"time":"2024-01-01T00:00:00Z","operation":"TagCreate","variables":{},"payload":{"Tag":{"id":"{{ template "id1_string" }}","key":"env","value":"prod"},"Errors":[]}}
+{"time":"2024-01-01T00:00:01Z","operation":"TagCreate","variables":{},"payload":{"Tag":{"id":"","key":"env","value":"prod"}},"error":"network"}
+{"time":"2024-01-01T00:00:02Z","operation":"TeamUpdate","variables":{},"payload":{},"prior":{"Alias":"platform","Id":"{{ template "id3_string" }}","Name":"Platform","Responsibilities":"Everything","Manager":{"Email":""},"ParentTeam":{"Alias":"","Id":""}}}
+{"time":"2024-01-01T00:00:03Z","operation":"ContactCreate","variables":{},"payload":{}}
+{"time":"2024-01-01T00:00:04Z","operation":"ToolCreate","variables":{},"payload":{"Tool":{"id":""},"Errors":[]}}
+`
+	testRequestOne := NewTestRequest(
+		`"mutation TeamUpdate($input:TeamUpdateInput!){teamUpdate(input: $input){team{alias,id,aliases,contacts{address,displayName,id,type},group{alias,id},htmlUrl,manager{id,email,htmlUrl,name,role},members{nodes{id,email,htmlUrl,name,role},{{ template "pagination_request" }},totalCount},memberships{nodes{team{alias,id},role,user{id,email}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount},name,parentTeam{alias,id},responsibilities,tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount}},errors{message,path}}}"`,
+		`{"input": { {{ template "id3" }}, "name": "Platform", "responsibilities": "Everything", "parentTeam": null }}`,
+		`{"data": { "teamUpdate": { "team": { "alias": "platform", {{ template "id3" }}, "name": "Platform" }, "errors": [] }}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"mutation TagDelete($input:TagDeleteInput!){tagDelete(input: $input){errors{message,path}}}"`,
+		`{"input": { {{ template "id1" }} }}`,
+		`{"data": { "tagDelete": { "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "journal/undo", testRequestOne, testRequestTwo)
+	entries, err := ol.ReadJournal(strings.NewReader(NewTestDataTemplater().ParseTemplatedString(journal)))
+	autopilot.Ok(t, err)
+	// Act
+	err = client.Undo(entries)
+	// Assert
+	autopilot.Equals(t, "ContactCreate at 2024-01-01T00:00:03Z: undo is not supported", err.Error())
+}
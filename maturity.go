@@ -15,6 +15,16 @@ type ServiceMaturity struct {
 	MaturityReport MaturityReport
 }
 
+// ServiceMaturityDetails is a ServiceMaturity with the fields needed to aggregate reports by team, tier and lifecycle
+type ServiceMaturityDetails struct {
+	Id             ID
+	Name           string
+	Owner          TeamId
+	Tier           Tier
+	Lifecycle      Lifecycle
+	MaturityReport MaturityReport
+}
+
 // Get Given a 'category' name returns the 'Level'
 func (s *MaturityReport) Get(category string) *Level {
 	for _, breakdown := range s.CategoryBreakdown {
@@ -64,3 +74,30 @@ func (c *Client) ListServicesMaturity() ([]ServiceMaturity, error) {
 
 	return output, nil
 }
+
+func (c *Client) ListServicesMaturityDetails() ([]ServiceMaturityDetails, error) {
+	var q struct {
+		Account struct {
+			Services struct {
+				Nodes    []ServiceMaturityDetails
+				PageInfo PageInfo
+			} `graphql:"services(after: $after, first: $first)"`
+		}
+	}
+	v := c.InitialPageVariables()
+
+	var output []ServiceMaturityDetails
+	if err := c.Query(&q, v, WithName("ServiceMaturityDetailsList")); err != nil {
+		return nil, err
+	}
+	output = append(output, q.Account.Services.Nodes...)
+	for q.Account.Services.PageInfo.HasNextPage {
+		v["after"] = q.Account.Services.PageInfo.End
+		if err := c.Query(&q, v, WithName("ServiceMaturityDetailsList")); err != nil {
+			return nil, err
+		}
+		output = append(output, q.Account.Services.Nodes...)
+	}
+
+	return output, nil
+}
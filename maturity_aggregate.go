@@ -0,0 +1,189 @@
+package opslevel
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+)
+
+// LevelDistribution counts the number of services per level alias
+type LevelDistribution map[string]int
+
+type CategoryBottleneck struct {
+	Category        Category
+	LowestLevel     Level
+	ServicesLimited int // Number of services whose overall level is held back by this category
+}
+
+// MaturityAggregate groups level distributions by team alias, tier alias, lifecycle alias and category name.
+// Services without an owner, tier or lifecycle are grouped under the empty string.
+type MaturityAggregate struct {
+	Overall     LevelDistribution
+	ByTeam      map[string]LevelDistribution
+	ByTier      map[string]LevelDistribution
+	ByLifecycle map[string]LevelDistribution
+	ByCategory  map[string]LevelDistribution
+	Bottlenecks []CategoryBottleneck
+}
+
+type MaturitySnapshot struct {
+	Date     time.Time                `json:"date"`
+	Services []ServiceMaturityDetails `json:"services"`
+}
+
+type MaturityMovement struct {
+	Id   ID
+	Name string
+	From Level
+	To   Level
+}
+
+type MaturitySnapshotDiff struct {
+	From      time.Time
+	To        time.Time
+	MovedUp   []MaturityMovement
+	MovedDown []MaturityMovement
+	Added     []ServiceMaturityDetails
+	Removed   []ServiceMaturityDetails
+}
+
+func (d LevelDistribution) add(level Level) {
+	d[level.Alias] += 1
+}
+
+func addToGroup(groups map[string]LevelDistribution, key string, level Level) {
+	if _, ok := groups[key]; !ok {
+		groups[key] = LevelDistribution{}
+	}
+	groups[key].add(level)
+}
+
+func AggregateMaturity(services []ServiceMaturityDetails) *MaturityAggregate {
+	output := &MaturityAggregate{
+		Overall:     LevelDistribution{},
+		ByTeam:      map[string]LevelDistribution{},
+		ByTier:      map[string]LevelDistribution{},
+		ByLifecycle: map[string]LevelDistribution{},
+		ByCategory:  map[string]LevelDistribution{},
+	}
+	bottlenecks := map[ID]*CategoryBottleneck{}
+	for _, service := range services {
+		overall := service.MaturityReport.OverallLevel
+		output.Overall.add(overall)
+		addToGroup(output.ByTeam, service.Owner.Alias, overall)
+		addToGroup(output.ByTier, service.Tier.Alias, overall)
+		addToGroup(output.ByLifecycle, service.Lifecycle.Alias, overall)
+		for _, breakdown := range service.MaturityReport.CategoryBreakdown {
+			// Categories without checks for the service have no level
+			if breakdown.Level.Id == "" {
+				continue
+			}
+			addToGroup(output.ByCategory, breakdown.Category.Name, breakdown.Level)
+			bottleneck, ok := bottlenecks[breakdown.Category.Id]
+			if !ok {
+				bottleneck = &CategoryBottleneck{Category: breakdown.Category, LowestLevel: breakdown.Level}
+				bottlenecks[breakdown.Category.Id] = bottleneck
+			}
+			if breakdown.Level.Index < bottleneck.LowestLevel.Index {
+				bottleneck.LowestLevel = breakdown.Level
+			}
+			if overall.Id != "" && breakdown.Level.Index == overall.Index {
+				bottleneck.ServicesLimited += 1
+			}
+		}
+	}
+	for _, bottleneck := range bottlenecks {
+		output.Bottlenecks = append(output.Bottlenecks, *bottleneck)
+	}
+	sort.Slice(output.Bottlenecks, func(i, j int) bool {
+		a, b := output.Bottlenecks[i], output.Bottlenecks[j]
+		if a.ServicesLimited != b.ServicesLimited {
+			return a.ServicesLimited > b.ServicesLimited
+		}
+		return a.Category.Name < b.Category.Name
+	})
+	return output
+}
+
+//#region Snapshots
+
+func NewMaturitySnapshot(date time.Time, services []ServiceMaturityDetails) *MaturitySnapshot {
+	return &MaturitySnapshot{
+		Date:     date,
+		Services: services,
+	}
+}
+
+func (c *Client) TakeMaturitySnapshot() (*MaturitySnapshot, error) {
+	services, err := c.ListServicesMaturityDetails()
+	if err != nil {
+		return nil, err
+	}
+	return NewMaturitySnapshot(time.Now().UTC(), services), nil
+}
+
+func LoadMaturitySnapshot(path string) (*MaturitySnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var output MaturitySnapshot
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+func (s *MaturitySnapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (s *MaturitySnapshot) Aggregate() *MaturityAggregate {
+	return AggregateMaturity(s.Services)
+}
+
+// DiffMaturitySnapshots Given two snapshots returns the services that moved up or down a level between them
+func DiffMaturitySnapshots(before *MaturitySnapshot, after *MaturitySnapshot) *MaturitySnapshotDiff {
+	output := &MaturitySnapshotDiff{
+		From: before.Date,
+		To:   after.Date,
+	}
+	previous := map[ID]ServiceMaturityDetails{}
+	for _, service := range before.Services {
+		previous[service.Id] = service
+	}
+	current := map[ID]bool{}
+	for _, service := range after.Services {
+		current[service.Id] = true
+		old, ok := previous[service.Id]
+		if !ok {
+			output.Added = append(output.Added, service)
+			continue
+		}
+		movement := MaturityMovement{
+			Id:   service.Id,
+			Name: service.Name,
+			From: old.MaturityReport.OverallLevel,
+			To:   service.MaturityReport.OverallLevel,
+		}
+		switch {
+		case movement.To.Index > movement.From.Index:
+			output.MovedUp = append(output.MovedUp, movement)
+		case movement.To.Index < movement.From.Index:
+			output.MovedDown = append(output.MovedDown, movement)
+		}
+	}
+	for _, service := range before.Services {
+		if !current[service.Id] {
+			output.Removed = append(output.Removed, service)
+		}
+	}
+	return output
+}
+
+//#endregion
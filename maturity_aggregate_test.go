@@ -0,0 +1,96 @@
+package opslevel_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+var (
+	bronze   = ol.Level{Id: "l1", Alias: "bronze", Index: 1}
+	silver   = ol.Level{Id: "l2", Alias: "silver", Index: 2}
+	gold     = ol.Level{Id: "l3", Alias: "gold", Index: 3}
+	security = ol.Category{Id: "c1", Name: "Security"}
+	quality  = ol.Category{Id: "c2", Name: "Quality"}
+)
+
+func newTestServiceMaturity(id ol.ID, team string, tier string, overall ol.Level, sec ol.Level, qual ol.Level) ol.ServiceMaturityDetails {
+	return ol.ServiceMaturityDetails{
+		Id:    id,
+		Name:  string(id),
+		Owner: ol.TeamId{Alias: team},
+		Tier:  ol.Tier{Alias: tier},
+		MaturityReport: ol.MaturityReport{
+			OverallLevel: overall,
+			CategoryBreakdown: []ol.CategoryBreakdown{
+				{Category: security, Level: sec},
+				{Category: quality, Level: qual},
+			},
+		},
+	}
+}
+
+func TestAggregateMaturity(t *testing.T) {
+	// Arrange
+	services := []ol.ServiceMaturityDetails{
+		newTestServiceMaturity("s1", "platform", "tier_1", bronze, bronze, gold),
+		newTestServiceMaturity("s2", "platform", "tier_2", silver, gold, silver),
+		newTestServiceMaturity("s3", "devs", "tier_1", silver, silver, ol.Level{}),
+	}
+	// Act
+	result := ol.AggregateMaturity(services)
+	// Assert
+	autopilot.Equals(t, ol.LevelDistribution{"bronze": 1, "silver": 2}, result.Overall)
+	autopilot.Equals(t, ol.LevelDistribution{"bronze": 1, "silver": 1}, result.ByTeam["platform"])
+	autopilot.Equals(t, ol.LevelDistribution{"bronze": 1, "silver": 1}, result.ByTier["tier_1"])
+	autopilot.Equals(t, ol.LevelDistribution{"bronze": 1, "silver": 1, "gold": 1}, result.ByCategory["Security"])
+	autopilot.Equals(t, ol.LevelDistribution{"gold": 1, "silver": 1}, result.ByCategory["Quality"])
+	autopilot.Equals(t, 2, len(result.Bottlenecks))
+	autopilot.Equals(t, "Security", result.Bottlenecks[0].Category.Name)
+	autopilot.Equals(t, 2, result.Bottlenecks[0].ServicesLimited)
+	autopilot.Equals(t, "bronze", result.Bottlenecks[0].LowestLevel.Alias)
+	autopilot.Equals(t, 1, result.Bottlenecks[1].ServicesLimited)
+}
+
+func TestDiffMaturitySnapshots(t *testing.T) {
+	// Arrange
+	before := ol.NewMaturitySnapshot(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), []ol.ServiceMaturityDetails{
+		newTestServiceMaturity("s1", "platform", "tier_1", bronze, bronze, gold),
+		newTestServiceMaturity("s2", "platform", "tier_2", silver, gold, silver),
+		newTestServiceMaturity("s3", "devs", "tier_1", silver, silver, silver),
+	})
+	after := ol.NewMaturitySnapshot(time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), []ol.ServiceMaturityDetails{
+		newTestServiceMaturity("s1", "platform", "tier_1", silver, silver, gold),
+		newTestServiceMaturity("s2", "platform", "tier_2", bronze, gold, bronze),
+		newTestServiceMaturity("s4", "devs", "tier_1", gold, gold, gold),
+	})
+	// Act
+	result := ol.DiffMaturitySnapshots(before, after)
+	// Assert
+	autopilot.Equals(t, 1, len(result.MovedUp))
+	autopilot.Equals(t, ol.ID("s1"), result.MovedUp[0].Id)
+	autopilot.Equals(t, "silver", result.MovedUp[0].To.Alias)
+	autopilot.Equals(t, 1, len(result.MovedDown))
+	autopilot.Equals(t, ol.ID("s2"), result.MovedDown[0].Id)
+	autopilot.Equals(t, ol.ID("s4"), result.Added[0].Id)
+	autopilot.Equals(t, ol.ID("s3"), result.Removed[0].Id)
+}
+
+func TestMaturitySnapshotSaveAndLoad(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "maturity.json")
+	snapshot := ol.NewMaturitySnapshot(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), []ol.ServiceMaturityDetails{
+		newTestServiceMaturity("s1", "platform", "tier_1", bronze, bronze, gold),
+	})
+	// Act
+	err := snapshot.Save(path)
+	result, loadErr := ol.LoadMaturitySnapshot(path)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Ok(t, loadErr)
+	autopilot.Equals(t, snapshot.Date, result.Date)
+	autopilot.Equals(t, snapshot.Services, result.Services)
+}
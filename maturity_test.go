@@ -28,3 +28,20 @@ func TestListServicesMaturity(t *testing.T) {
 	autopilot.Equals(t, "Example", result[0].Name)
 	autopilot.Equals(t, "Gold", result[0].MaturityReport.Get("Quality").Name)
 }
+
+func TestListServicesMaturityDetails(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query ServiceMaturityDetailsList($after:String!$first:Int!){account{services(after: $after, first: $first){nodes{id,name,owner{alias,id},tier{alias,description,id,index,name},lifecycle{alias,description,id,index,name},maturityReport{categoryBreakdown{category{id,name},level{alias,description,id,index,name}},overallLevel{alias,description,id,index,name}}},{{ template "pagination_request" }}}}}"`,
+		`{{ template "pagination_initial_query_variables" }}`,
+		`{ "data": { "account": { "services": { "nodes": [ { {{ template "id1" }}, "name": "Example", "owner": { "alias": "platform", {{ template "id2" }} }, "tier": {{ template "tier_1" }}, "lifecycle": {{ template "lifecycle_1" }}, "maturityReport": { "categoryBreakdown": [ { "category": {{ template "category_1" }}, "level": {{ template "level_1" }} } ], "overallLevel": {{ template "level_1" }} } } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	client := BestTestClient(t, "maturity/services_details", testRequest)
+	// Act
+	result, err := client.ListServicesMaturityDetails()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result))
+	autopilot.Equals(t, "platform", result[0].Owner.Alias)
+	autopilot.Equals(t, "Example", result[0].MaturityReport.OverallLevel.Name)
+}
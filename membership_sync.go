@@ -0,0 +1,279 @@
+package opslevel
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type MembershipChangeType string
+
+const (
+	MembershipChangeTypeInvite MembershipChangeType = "invite"
+	MembershipChangeTypeAdd    MembershipChangeType = "add"
+	MembershipChangeTypeRemove MembershipChangeType = "remove"
+)
+
+type DesiredMember struct {
+	Email string `json:"email" yaml:"email"`
+	Role  string `json:"role,omitempty" yaml:"role,omitempty"`
+}
+
+// DesiredMemberships maps a team alias to the members the team should have
+type DesiredMemberships map[string][]DesiredMember
+
+// MembershipSource provides the desired team memberships from an external directory (IdP groups, HR system, etc.)
+type MembershipSource interface {
+	Load() (DesiredMemberships, error)
+}
+
+type MembershipChange struct {
+	Type  MembershipChangeType `json:"type"`
+	Team  string               `json:"team,omitempty"`
+	Email string               `json:"email"`
+	Role  string               `json:"role,omitempty"`
+}
+
+func (c MembershipChange) String() string {
+	switch c.Type {
+	case MembershipChangeTypeInvite:
+		return fmt.Sprintf("invite user '%s'", c.Email)
+	case MembershipChangeTypeRemove:
+		return fmt.Sprintf("remove '%s' (%s) from team '%s'", c.Email, c.Role, c.Team)
+	default:
+		return fmt.Sprintf("add '%s' (%s) to team '%s'", c.Email, c.Role, c.Team)
+	}
+}
+
+type MembershipSyncResult struct {
+	Changes []MembershipChange
+	Applied []MembershipChange
+	Errors  []error
+}
+
+// Err joins all the errors encountered while applying changes
+func (r *MembershipSyncResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	var messages []string
+	for _, err := range r.Errors {
+		messages = append(messages, err.Error())
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+type MembershipSyncer struct {
+	Client      *Client
+	Source      MembershipSource
+	DryRun      bool
+	DefaultRole string // Used when a DesiredMember has no role - defaults to "member"
+
+	teams map[string]TeamId
+}
+
+func NewMembershipSyncer(client *Client, source MembershipSource, dryRun bool) *MembershipSyncer {
+	return &MembershipSyncer{
+		Client:      client,
+		Source:      source,
+		DryRun:      dryRun,
+		DefaultRole: "member",
+	}
+}
+
+//#region Sources
+
+// FileMembershipSource reads desired memberships from a CSV or JSON file based on its extension
+type FileMembershipSource struct {
+	Path string
+}
+
+func (s *FileMembershipSource) Load() (DesiredMemberships, error) {
+	file, err := os.Open(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	switch strings.ToLower(filepath.Ext(s.Path)) {
+	case ".csv":
+		return ReadMembershipsCSV(file)
+	case ".json":
+		return ReadMembershipsJSON(file)
+	}
+	return nil, fmt.Errorf("unsupported membership file format '%s'", s.Path)
+}
+
+// ReadMembershipsCSV parses rows of 'team,email,role' - a header row and the role column are optional
+func ReadMembershipsCSV(reader io.Reader) (DesiredMemberships, error) {
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	output := DesiredMemberships{}
+	for i, record := range records {
+		if i == 0 && len(record) > 1 && strings.EqualFold(record[0], "team") && strings.EqualFold(record[1], "email") {
+			continue
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("membership csv line %d: expected at least 2 columns 'team,email' got %d", i+1, len(record))
+		}
+		member := DesiredMember{Email: strings.TrimSpace(record[1])}
+		if len(record) > 2 {
+			member.Role = strings.TrimSpace(record[2])
+		}
+		team := strings.TrimSpace(record[0])
+		output[team] = append(output[team], member)
+	}
+	return output, nil
+}
+
+// ReadMembershipsJSON parses an object of team alias to a list of '{"email": "", "role": ""}'
+func ReadMembershipsJSON(reader io.Reader) (DesiredMemberships, error) {
+	output := DesiredMemberships{}
+	if err := json.NewDecoder(reader).Decode(&output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+//#endregion
+
+//#region Helpers
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// DiffMemberships Given the 'desired' members of a team and its 'current' memberships returns the changes needed to converge
+func DiffMemberships(team string, desired []DesiredMember, current []TeamMembership, defaultRole string) []MembershipChange {
+	var output []MembershipChange
+	wanted := map[string]DesiredMember{}
+	for _, member := range desired {
+		if member.Role == "" {
+			member.Role = defaultRole
+		}
+		wanted[normalizeEmail(member.Email)] = member
+	}
+	existing := map[string]TeamMembership{}
+	for _, membership := range current {
+		existing[normalizeEmail(membership.User.Email)] = membership
+	}
+	for _, membership := range current {
+		email := normalizeEmail(membership.User.Email)
+		member, ok := wanted[email]
+		if !ok || member.Role != membership.Role {
+			output = append(output, MembershipChange{Type: MembershipChangeTypeRemove, Team: team, Email: membership.User.Email, Role: membership.Role})
+		}
+	}
+	added := map[string]bool{}
+	for _, member := range desired {
+		email := normalizeEmail(member.Email)
+		if added[email] {
+			continue
+		}
+		added[email] = true
+		member = wanted[email]
+		if membership, ok := existing[email]; ok && membership.Role == member.Role {
+			continue
+		}
+		output = append(output, MembershipChange{Type: MembershipChangeTypeAdd, Team: team, Email: member.Email, Role: member.Role})
+	}
+	return output
+}
+
+//#endregion
+
+// Plan loads the desired memberships and computes the changes without applying them
+func (s *MembershipSyncer) Plan() ([]MembershipChange, error) {
+	desired, err := s.Source.Load()
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.Client.ListUsers(nil)
+	if err != nil {
+		return nil, err
+	}
+	known := map[string]bool{}
+	for _, user := range users.Nodes {
+		known[normalizeEmail(user.Email)] = true
+	}
+
+	teams := make([]string, 0, len(desired))
+	for team := range desired {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+
+	resolver := s.Client.NewResolver()
+	resolver.Want(ResolverKindTeam, teams...)
+	if err := resolver.Flush(); err != nil {
+		return nil, err
+	}
+
+	s.teams = map[string]TeamId{}
+	var invites, changes []MembershipChange
+	for _, alias := range teams {
+		id, err := resolver.Resolve(ResolverKindTeam, alias)
+		if err != nil {
+			return nil, err
+		}
+		team := Team{TeamId: TeamId{Id: id, Alias: alias}}
+		if _, err := team.GetMemberships(s.Client, nil); err != nil {
+			return nil, err
+		}
+		s.teams[alias] = team.TeamId
+		for _, member := range desired[alias] {
+			email := normalizeEmail(member.Email)
+			if !known[email] {
+				known[email] = true
+				invites = append(invites, MembershipChange{Type: MembershipChangeTypeInvite, Email: member.Email})
+			}
+		}
+		changes = append(changes, DiffMemberships(alias, desired[alias], team.Memberships.Nodes, s.DefaultRole)...)
+	}
+	return append(invites, changes...), nil
+}
+
+// Sync computes the plan and, unless DryRun is set, invites missing users and applies membership removals and additions
+func (s *MembershipSyncer) Sync() (*MembershipSyncResult, error) {
+	changes, err := s.Plan()
+	if err != nil {
+		return nil, err
+	}
+	result := &MembershipSyncResult{Changes: changes}
+	if s.DryRun {
+		return result, nil
+	}
+	for _, change := range changes {
+		if change.Type == MembershipChangeTypeInvite {
+			if _, err := s.Client.InviteUser(change.Email, UserInput{}); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("%s: %w", change, err))
+				continue
+			}
+			result.Applied = append(result.Applied, change)
+			continue
+		}
+		team := s.teams[change.Team]
+		membership := TeamMembershipUserInput{User: UserIdentifierInput{Email: change.Email}, Role: change.Role}
+		if change.Type == MembershipChangeTypeRemove {
+			_, err = s.Client.RemoveMemberships(&team, membership)
+		} else {
+			_, err = s.Client.AddMemberships(&team, membership)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %w", change, err))
+			continue
+		}
+		result.Applied = append(result.Applied, change)
+	}
+	return result, result.Err()
+}
@@ -0,0 +1,115 @@
+package opslevel_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestReadMembershipsCSV(t *testing.T) {
+	// Arrange
+	data := `team,email,role
+platform,kyle@opslevel.com,manager
+platform, edgar@opslevel.com
+devs,matthew@opslevel.com,member
+`
+	// Act
+	result, err := ol.ReadMembershipsCSV(strings.NewReader(data))
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, len(result["platform"]))
+	autopilot.Equals(t, "manager", result["platform"][0].Role)
+	autopilot.Equals(t, "edgar@opslevel.com", result["platform"][1].Email)
+	autopilot.Equals(t, "", result["platform"][1].Role)
+	autopilot.Equals(t, "matthew@opslevel.com", result["devs"][0].Email)
+}
+
+func TestReadMembershipsJSON(t *testing.T) {
+	// Arrange
+	data := `{"platform": [{"email": "kyle@opslevel.com", "role": "manager"}, {"email": "edgar@opslevel.com"}]}`
+	// Act
+	result, err := ol.ReadMembershipsJSON(strings.NewReader(data))
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, len(result["platform"]))
+	autopilot.Equals(t, "manager", result["platform"][0].Role)
+}
+
+func TestDiffMemberships(t *testing.T) {
+	// Arrange
+	desired := []ol.DesiredMember{
+		{Email: "Kyle@OpsLevel.com", Role: "manager"},
+		{Email: "edgar@opslevel.com"},
+		{Email: "matthew@opslevel.com"},
+		{Email: "matthew@opslevel.com"},
+	}
+	current := []ol.TeamMembership{
+		{Role: "manager", User: ol.UserId{Email: "kyle@opslevel.com"}},
+		{Role: "manager", User: ol.UserId{Email: "edgar@opslevel.com"}},
+		{Role: "member", User: ol.UserId{Email: "john@opslevel.com"}},
+	}
+	// Act
+	result := ol.DiffMemberships("platform", desired, current, "member")
+	// Assert
+	autopilot.Equals(t, []ol.MembershipChange{
+		{Type: ol.MembershipChangeTypeRemove, Team: "platform", Email: "edgar@opslevel.com", Role: "manager"},
+		{Type: ol.MembershipChangeTypeRemove, Team: "platform", Email: "john@opslevel.com", Role: "member"},
+		{Type: ol.MembershipChangeTypeAdd, Team: "platform", Email: "edgar@opslevel.com", Role: "member"},
+		{Type: ol.MembershipChangeTypeAdd, Team: "platform", Email: "matthew@opslevel.com", Role: "member"},
+	}, result)
+}
+
+type testMembershipSource ol.DesiredMemberships
+
+func (s testMembershipSource) Load() (ol.DesiredMemberships, error) {
+	return ol.DesiredMemberships(s), nil
+}
+
+func TestMembershipSyncerPlan(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"query UserList($after:String!$first:Int!){account{users(after: $after, first: $first){nodes{id,email,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}"`,
+		`{ {{ template "first_page_variables" }} }`,
+		`{ "data": { "account": { "users": { "nodes": [ { {{ template "id1" }}, "email": "kyle@opslevel.com" } ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"query IdentifierResolve($r0:String!$r1:String!){account{r0: team(alias: $r0){id},r1: team(alias: $r1){id}}}"`,
+		`{ "r0": "devs", "r1": "platform" }`,
+		`{ "data": { "account": { "r0": { {{ template "id2" }} }, "r1": { {{ template "id3" }} } }}}`,
+	)
+	testRequestThree := NewTestRequest(
+		`"query TeamMembersList($after:String!$first:Int!$team:ID!){account{team(id: $team){memberships(after: $after, first: $first){nodes{team{alias,id},role,user{id,email}},{{ template "pagination_request" }},totalCount}}}}"`,
+		`{ {{ template "first_page_variables" }}, "team": "{{ template "id2_string" }}" }`,
+		`{ "data": { "account": { "team": { "memberships": { "nodes": [], {{ template "no_pagination_response" }}, "totalCount": 0 }}}}}`,
+	)
+	testRequestFour := NewTestRequest(
+		`"query TeamMembersList($after:String!$first:Int!$team:ID!){account{team(id: $team){memberships(after: $after, first: $first){nodes{team{alias,id},role,user{id,email}},{{ template "pagination_request" }},totalCount}}}}"`,
+		`{ {{ template "first_page_variables" }}, "team": "{{ template "id3_string" }}" }`,
+		`{ "data": { "account": { "team": { "memberships": { "nodes": [ { "role": "manager", "user": { {{ template "id1" }}, "email": "kyle@opslevel.com" } } ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}}`,
+	)
+	client := BestTestClient(t, "membership_sync/plan", testRequestOne, testRequestTwo, testRequestThree, testRequestFour)
+	source := testMembershipSource{
+		"platform": {{Email: "kyle@opslevel.com", Role: "manager"}},
+		"devs":     {{Email: "edgar@opslevel.com"}},
+	}
+	// Act
+	result, err := ol.NewMembershipSyncer(client, source, true).Plan()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.MembershipChange{
+		{Type: ol.MembershipChangeTypeInvite, Email: "edgar@opslevel.com"},
+		{Type: ol.MembershipChangeTypeAdd, Team: "devs", Email: "edgar@opslevel.com", Role: "member"},
+	}, result)
+}
+
+func TestMembershipSyncResultErrKeepsPercent(t *testing.T) {
+	// Arrange
+	result := ol.MembershipSyncResult{Errors: []error{errors.New("quota at 100%")}}
+	// Act
+	err := result.Err()
+	// Assert
+	autopilot.Equals(t, "quota at 100%", err.Error())
+}
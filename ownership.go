@@ -0,0 +1,282 @@
+package opslevel
+
+import (
+	"fmt"
+	"slices"
+)
+
+type OwnedEntityType string
+
+const (
+	OwnedEntityTypeService                OwnedEntityType = "Service"
+	OwnedEntityTypeRepository             OwnedEntityType = "Repository"
+	OwnedEntityTypeDomain                 OwnedEntityType = "Domain"
+	OwnedEntityTypeSystem                 OwnedEntityType = "System"
+	OwnedEntityTypeInfrastructureResource OwnedEntityType = "InfrastructureResource"
+	OwnedEntityTypeSecret                 OwnedEntityType = "Secret"
+)
+
+// OwnedEntity is a joined view over the different owner fields found on
+// services, repositories, domains, systems, infrastructure resources and secrets
+type OwnedEntity struct {
+	Type  OwnedEntityType `json:"type"`
+	Id    ID              `json:"id"`
+	Alias string          `json:"alias,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Owner TeamId          `json:"owner"`
+}
+
+type OwnershipReport struct {
+	Team     TeamId        `json:"team"`
+	Teams    []TeamId      `json:"teams"` // The team and, if requested, all of its descendants
+	Entities []OwnedEntity `json:"entities"`
+}
+
+type OrphanReport struct {
+	Unowned      []OwnedEntity `json:"unowned"`
+	DeletedOwner []OwnedEntity `json:"deletedOwner"` // Entities whose owner is no longer an existing team
+}
+
+// OwnershipIndex joins a list of teams with a list of owned entities so that
+// ownership reports can be computed without additional API calls
+type OwnershipIndex struct {
+	Teams    []Team
+	Entities []OwnedEntity
+
+	teamsById    map[ID]Team
+	teamsByAlias map[string]Team
+	children     map[ID][]ID
+}
+
+func (e *OwnedEntity) IsOwned() bool {
+	return e.Owner.Id != "" || e.Owner.Alias != ""
+}
+
+func (r *OwnershipReport) ByType(entityType OwnedEntityType) []OwnedEntity {
+	var output []OwnedEntity
+	for _, entity := range r.Entities {
+		if entity.Type == entityType {
+			output = append(output, entity)
+		}
+	}
+	return output
+}
+
+func firstAlias(aliases []string) string {
+	if len(aliases) == 0 {
+		return ""
+	}
+	return aliases[0]
+}
+
+func NewOwnedEntityFromService(s Service) OwnedEntity {
+	return OwnedEntity{Type: OwnedEntityTypeService, Id: s.Id, Alias: firstAlias(s.Aliases), Name: s.Name, Owner: s.Owner}
+}
+
+func NewOwnedEntityFromRepository(r Repository) OwnedEntity {
+	return OwnedEntity{Type: OwnedEntityTypeRepository, Id: r.Id, Alias: r.DefaultAlias, Name: r.Name, Owner: r.Owner}
+}
+
+func NewOwnedEntityFromDomain(d Domain) OwnedEntity {
+	return OwnedEntity{Type: OwnedEntityTypeDomain, Id: d.Id, Alias: firstAlias(d.Aliases), Name: d.Name, Owner: d.Owner.OnTeam.AsTeam()}
+}
+
+func NewOwnedEntityFromSystem(s System) OwnedEntity {
+	return OwnedEntity{Type: OwnedEntityTypeSystem, Id: s.Id, Alias: firstAlias(s.Aliases), Name: s.Name, Owner: s.Owner.OnTeam.AsTeam()}
+}
+
+func NewOwnedEntityFromInfrastructure(i InfrastructureResource) OwnedEntity {
+	return OwnedEntity{Type: OwnedEntityTypeInfrastructureResource, Id: ID(i.Id), Alias: firstAlias(i.Aliases), Name: i.Name, Owner: i.Owner.OnTeam.AsTeam()}
+}
+
+func NewOwnedEntityFromSecret(s Secret) OwnedEntity {
+	return OwnedEntity{Type: OwnedEntityTypeSecret, Id: s.ID, Alias: s.Alias, Name: s.Alias, Owner: s.Owner}
+}
+
+func NewOwnershipIndex(teams []Team, entities []OwnedEntity) *OwnershipIndex {
+	index := &OwnershipIndex{
+		Teams:        teams,
+		Entities:     entities,
+		teamsById:    make(map[ID]Team),
+		teamsByAlias: make(map[string]Team),
+		children:     make(map[ID][]ID),
+	}
+	for _, team := range teams {
+		index.teamsById[team.Id] = team
+		index.teamsByAlias[team.Alias] = team
+		for _, alias := range team.Aliases {
+			index.teamsByAlias[alias] = team
+		}
+		if team.ParentTeam.Id != "" {
+			index.children[team.ParentTeam.Id] = append(index.children[team.ParentTeam.Id], team.Id)
+		}
+	}
+	return index
+}
+
+// GetTeam Given a team 'identifier' (id or alias) returns the 'Team'
+func (i *OwnershipIndex) GetTeam(identifier string) (*Team, bool) {
+	if team, ok := i.teamsById[ID(identifier)]; ok {
+		return &team, true
+	}
+	if team, ok := i.teamsByAlias[identifier]; ok {
+		return &team, true
+	}
+	return nil, false
+}
+
+// Descendants returns every team below the given team id in the hierarchy
+func (i *OwnershipIndex) Descendants(id ID) []TeamId {
+	var output []TeamId
+	visited := map[ID]bool{id: true}
+	queue := slices.Clone(i.children[id])
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		output = append(output, i.teamsById[current].TeamId)
+		queue = append(queue, i.children[current]...)
+	}
+	return output
+}
+
+func (i *OwnershipIndex) isOwnedBy(entity OwnedEntity, teams []TeamId) bool {
+	for _, team := range teams {
+		if entity.Owner.Id != "" && entity.Owner.Id == team.Id {
+			return true
+		}
+		if entity.Owner.Id == "" && entity.Owner.Alias != "" && entity.Owner.Alias == team.Alias {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *OwnershipIndex) isKnownTeam(owner TeamId) bool {
+	if owner.Id != "" {
+		_, ok := i.teamsById[owner.Id]
+		return ok
+	}
+	_, ok := i.teamsByAlias[owner.Alias]
+	return ok
+}
+
+// ReportFor lists every entity owned by the team 'identifier' (id or alias) optionally including its descendant teams
+func (i *OwnershipIndex) ReportFor(identifier string, includeDescendants bool) (*OwnershipReport, error) {
+	team, ok := i.GetTeam(identifier)
+	if !ok {
+		return nil, fmt.Errorf("team with identifier '%s' not found", identifier)
+	}
+	report := OwnershipReport{
+		Team:  team.TeamId,
+		Teams: []TeamId{team.TeamId},
+	}
+	if includeDescendants {
+		report.Teams = append(report.Teams, i.Descendants(team.Id)...)
+	}
+	for _, entity := range i.Entities {
+		if i.isOwnedBy(entity, report.Teams) {
+			report.Entities = append(report.Entities, entity)
+		}
+	}
+	return &report, nil
+}
+
+// Orphans lists every entity that is unowned or owned by a team that no longer exists
+func (i *OwnershipIndex) Orphans() *OrphanReport {
+	report := OrphanReport{}
+	for _, entity := range i.Entities {
+		if !entity.IsOwned() {
+			report.Unowned = append(report.Unowned, entity)
+		} else if !i.isKnownTeam(entity.Owner) {
+			report.DeletedOwner = append(report.DeletedOwner, entity)
+		}
+	}
+	return &report
+}
+
+// ListOwnedEntities fetches every service, repository, domain, system, infrastructure resource and secret in the account
+func (client *Client) ListOwnedEntities() ([]OwnedEntity, error) {
+	var output []OwnedEntity
+
+	services, err := client.ListServices(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range services.Nodes {
+		output = append(output, NewOwnedEntityFromService(item))
+	}
+
+	repositories, err := client.ListRepositories(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range repositories.Nodes {
+		output = append(output, NewOwnedEntityFromRepository(item))
+	}
+
+	domains, err := client.ListDomains(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range domains.Nodes {
+		output = append(output, NewOwnedEntityFromDomain(item))
+	}
+
+	systems, err := client.ListSystems(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range systems.Nodes {
+		output = append(output, NewOwnedEntityFromSystem(item))
+	}
+
+	infra, err := client.ListInfrastructure(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range infra.Nodes {
+		output = append(output, NewOwnedEntityFromInfrastructure(item))
+	}
+
+	secrets, err := client.ListSecretsVaultsSecret(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range secrets.Nodes {
+		output = append(output, NewOwnedEntityFromSecret(item))
+	}
+
+	return output, nil
+}
+
+func (client *Client) GetOwnershipIndex() (*OwnershipIndex, error) {
+	teams, err := client.ListTeams(nil)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := client.ListOwnedEntities()
+	if err != nil {
+		return nil, err
+	}
+	return NewOwnershipIndex(teams.Nodes, entities), nil
+}
+
+func (client *Client) GetOwnershipReport(team string, includeDescendants bool) (*OwnershipReport, error) {
+	index, err := client.GetOwnershipIndex()
+	if err != nil {
+		return nil, err
+	}
+	return index.ReportFor(team, includeDescendants)
+}
+
+func (client *Client) GetOrphanReport() (*OrphanReport, error) {
+	index, err := client.GetOwnershipIndex()
+	if err != nil {
+		return nil, err
+	}
+	return index.Orphans(), nil
+}
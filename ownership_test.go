@@ -0,0 +1,75 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func newTestOwnershipIndex() *ol.OwnershipIndex {
+	platform := ol.TeamId{Id: id1, Alias: "platform"}
+	infra := ol.TeamId{Id: id2, Alias: "infra"}
+	database := ol.TeamId{Id: id3, Alias: "database"}
+	deleted := ol.TeamId{Id: id4, Alias: "deleted"}
+	teams := []ol.Team{
+		{TeamId: platform, Aliases: []string{"platform", "platform_team"}},
+		{TeamId: infra, ParentTeam: platform},
+		{TeamId: database, ParentTeam: infra},
+	}
+	entities := []ol.OwnedEntity{
+		ol.NewOwnedEntityFromService(ol.Service{ServiceId: ol.ServiceId{Id: "s1", Aliases: []string{"api"}}, Name: "API", Owner: platform}),
+		ol.NewOwnedEntityFromRepository(ol.Repository{Id: "r1", DefaultAlias: "github.com:org/api", Owner: infra}),
+		ol.NewOwnedEntityFromDomain(ol.Domain{DomainId: ol.DomainId{Id: "d1"}, Owner: ol.EntityOwner{OnTeam: ol.EntityOwnerTeam{Id: database.Id, Alias: database.Alias}}}),
+		ol.NewOwnedEntityFromSystem(ol.System{SystemId: ol.SystemId{Id: "sy1"}}),
+		ol.NewOwnedEntityFromInfrastructure(ol.InfrastructureResource{Id: "i1", Owner: ol.EntityOwner{OnTeam: ol.EntityOwnerTeam{Id: deleted.Id, Alias: deleted.Alias}}}),
+		ol.NewOwnedEntityFromSecret(ol.Secret{ID: "se1", Alias: "token", Owner: platform}),
+	}
+	return ol.NewOwnershipIndex(teams, entities)
+}
+
+func TestOwnershipReport(t *testing.T) {
+	// Arrange
+	index := newTestOwnershipIndex()
+	// Act
+	result, err := index.ReportFor("platform_team", false)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "platform", result.Team.Alias)
+	autopilot.Equals(t, 2, len(result.Entities))
+	autopilot.Equals(t, 1, len(result.ByType(ol.OwnedEntityTypeService)))
+	autopilot.Equals(t, "token", result.ByType(ol.OwnedEntityTypeSecret)[0].Alias)
+}
+
+func TestOwnershipReportWithDescendants(t *testing.T) {
+	// Arrange
+	index := newTestOwnershipIndex()
+	// Act
+	result, err := index.ReportFor(string(id1), true)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 3, len(result.Teams))
+	autopilot.Equals(t, 4, len(result.Entities))
+	autopilot.Equals(t, ol.ID("d1"), result.ByType(ol.OwnedEntityTypeDomain)[0].Id)
+}
+
+func TestOwnershipReportMissingTeam(t *testing.T) {
+	// Arrange
+	index := newTestOwnershipIndex()
+	// Act
+	_, err := index.ReportFor("unknown", true)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for an unknown team")
+}
+
+func TestOwnershipOrphans(t *testing.T) {
+	// Arrange
+	index := newTestOwnershipIndex()
+	// Act
+	result := index.Orphans()
+	// Assert
+	autopilot.Equals(t, 1, len(result.Unowned))
+	autopilot.Equals(t, ol.OwnedEntityTypeSystem, result.Unowned[0].Type)
+	autopilot.Equals(t, 1, len(result.DeletedOwner))
+	autopilot.Equals(t, ol.ID("i1"), result.DeletedOwner[0].Id)
+}
@@ -0,0 +1,166 @@
+package opslevel
+
+// Projections let list calls fetch only the fields a caller needs. Pass any struct whose fields
+// follow the same naming (or graphql tags) as the full type, e.g.
+//
+//	type ServiceOwner struct {
+//		Id    ID
+//		Owner TeamId
+//	}
+//	services, err := ListServicesAs[ServiceOwner](client, nil)
+
+// ProjectionConnection is a page of nodes projected into T
+type ProjectionConnection[T any] struct {
+	Nodes      []T
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+// infrastructureProjectionConnection is a ProjectionConnection without totalCount, which infrastructureResources does not have
+type infrastructureProjectionConnection[T any] struct {
+	Nodes      []T
+	PageInfo   PageInfo
+	TotalCount int `graphql:"-"`
+}
+
+// ServiceSummary is a projection of Service without its tags, tools and repositories connections
+type ServiceSummary struct {
+	ServiceId
+	Name  string
+	Owner TeamId
+	Tier  Tier
+}
+
+// TeamSummary is a projection of Team without its contacts, memberships and tags
+type TeamSummary struct {
+	TeamId
+	Aliases []string
+	Name    string
+}
+
+// RepositorySummary is a projection of Repository without its services and tags
+type RepositorySummary struct {
+	Id           ID
+	DefaultAlias string
+	Name         string
+	Organization string
+	Owner        TeamId
+	Tier         Tier
+}
+
+// InfrastructureResourceSummary is a projection of InfrastructureResource without its data
+type InfrastructureResourceSummary struct {
+	Id      ID
+	Aliases []string
+	Name    string
+	Schema  string      `graphql:"type"`
+	Owner   EntityOwner `graphql:"owner"`
+}
+
+// ListServicesAs lists services fetching only the fields of T
+func ListServicesAs[T any](client *Client, variables *PayloadVariables) (*ProjectionConnection[T], error) {
+	var q struct {
+		Account struct {
+			Services ProjectionConnection[T] `graphql:"services(after: $after, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	if err := client.Query(&q, *variables, WithName("ServiceList")); err != nil {
+		return nil, err
+	}
+	for q.Account.Services.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Services.PageInfo.End
+		resp, err := ListServicesAs[T](client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Services.Nodes = append(q.Account.Services.Nodes, resp.Nodes...)
+		q.Account.Services.PageInfo = resp.PageInfo
+		q.Account.Services.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Services, nil
+}
+
+// ListTeamsAs lists teams fetching only the fields of T
+func ListTeamsAs[T any](client *Client, variables *PayloadVariables) (*ProjectionConnection[T], error) {
+	var q struct {
+		Account struct {
+			Teams ProjectionConnection[T] `graphql:"teams(after: $after, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	if err := client.Query(&q, *variables, WithName("TeamList")); err != nil {
+		return nil, err
+	}
+	for q.Account.Teams.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Teams.PageInfo.End
+		resp, err := ListTeamsAs[T](client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Teams.Nodes = append(q.Account.Teams.Nodes, resp.Nodes...)
+		q.Account.Teams.PageInfo = resp.PageInfo
+		q.Account.Teams.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Teams, nil
+}
+
+// ListRepositoriesAs lists repositories fetching only the fields of T
+func ListRepositoriesAs[T any](client *Client, variables *PayloadVariables) (*ProjectionConnection[T], error) {
+	var q struct {
+		Account struct {
+			Repositories ProjectionConnection[T] `graphql:"repositories(after: $after, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	if err := client.Query(&q, *variables, WithName("RepositoryList")); err != nil {
+		return nil, err
+	}
+	for q.Account.Repositories.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Repositories.PageInfo.End
+		resp, err := ListRepositoriesAs[T](client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.Repositories.Nodes = append(q.Account.Repositories.Nodes, resp.Nodes...)
+		q.Account.Repositories.PageInfo = resp.PageInfo
+		q.Account.Repositories.TotalCount += resp.TotalCount
+	}
+	return &q.Account.Repositories, nil
+}
+
+// ListInfrastructureAs lists infrastructure resources - unlike ListInfrastructure the '$all' variable is
+// not set so projections must not use the '@include(if: $all)' directive
+func ListInfrastructureAs[T any](client *Client, variables *PayloadVariables) (*ProjectionConnection[T], error) {
+	var q struct {
+		Account struct {
+			InfrastructureResources infrastructureProjectionConnection[T] `graphql:"infrastructureResources(after: $after, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	if err := client.Query(&q, *variables, WithName("InfrastructureResourceList")); err != nil {
+		return nil, err
+	}
+	for q.Account.InfrastructureResources.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.InfrastructureResources.PageInfo.End
+		resp, err := ListInfrastructureAs[T](client, variables)
+		if err != nil {
+			return nil, err
+		}
+		q.Account.InfrastructureResources.Nodes = append(q.Account.InfrastructureResources.Nodes, resp.Nodes...)
+		q.Account.InfrastructureResources.PageInfo = resp.PageInfo
+	}
+	return &ProjectionConnection[T]{
+		Nodes:      q.Account.InfrastructureResources.Nodes,
+		PageInfo:   q.Account.InfrastructureResources.PageInfo,
+		TotalCount: len(q.Account.InfrastructureResources.Nodes),
+	}, nil
+}
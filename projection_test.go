@@ -0,0 +1,81 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestListServicesAs(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"query ServiceList($after:String!$first:Int!){account{services(after: $after, first: $first){nodes{id,aliases,name,owner{alias,id},tier{alias,description,id,index,name}},{{ template "pagination_request" }},totalCount}}}"`,
+		`{ {{ template "first_page_variables" }} }`,
+		`{ "data": { "account": { "services": { "nodes": [ { {{ template "id1" }}, "aliases": ["foo"], "name": "Foo", "owner": { "alias": "platform", {{ template "id2" }} }, "tier": null } ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"query ServiceList($after:String!$first:Int!){account{services(after: $after, first: $first){nodes{id,aliases,name,owner{alias,id},tier{alias,description,id,index,name}},{{ template "pagination_request" }},totalCount}}}"`,
+		`{ {{ template "second_page_variables" }} }`,
+		`{ "data": { "account": { "services": { "nodes": [ { {{ template "id3" }}, "aliases": ["bar"], "name": "Bar", "owner": null, "tier": { "alias": "tier_1" } } ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	client := BestTestClient(t, "projection/services", testRequestOne, testRequestTwo)
+	// Act
+	result, err := ol.ListServicesAs[ol.ServiceSummary](client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, result.TotalCount)
+	autopilot.Equals(t, "platform", result.Nodes[0].Owner.Alias)
+	autopilot.Equals(t, "tier_1", result.Nodes[1].Tier.Alias)
+}
+
+func TestListTeamsAsCustomProjection(t *testing.T) {
+	// Arrange
+	type teamName struct {
+		Id   ol.ID
+		Name string
+	}
+	testRequest := NewTestRequest(
+		`"query TeamList($after:String!$first:Int!){account{teams(after: $after, first: $first){nodes{id,name},{{ template "pagination_request" }},totalCount}}}"`,
+		`{ {{ template "first_page_variables" }} }`,
+		`{ "data": { "account": { "teams": { "nodes": [ { {{ template "id1" }}, "name": "Platform" } ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}`,
+	)
+	client := BestTestClient(t, "projection/teams", testRequest)
+	// Act
+	result, err := ol.ListTeamsAs[teamName](client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "Platform", result.Nodes[0].Name)
+}
+
+func TestListRepositoriesAs(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query RepositoryList($after:String!$first:Int!){account{repositories(after: $after, first: $first){nodes{id,defaultAlias,name,organization,owner{alias,id},tier{alias,description,id,index,name}},{{ template "pagination_request" }},totalCount}}}"`,
+		`{ {{ template "first_page_variables" }} }`,
+		`{ "data": { "account": { "repositories": { "nodes": [ { {{ template "id1" }}, "defaultAlias": "github.com:opslevel/monorepo", "name": "monorepo", "organization": "opslevel" } ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}`,
+	)
+	client := BestTestClient(t, "projection/repositories", testRequest)
+	// Act
+	result, err := ol.ListRepositoriesAs[ol.RepositorySummary](client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "github.com:opslevel/monorepo", result.Nodes[0].DefaultAlias)
+}
+
+func TestListInfrastructureAs(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query InfrastructureResourceList($after:String!$first:Int!){account{infrastructureResources(after: $after, first: $first){nodes{id,aliases,name,type,owner{... on Team{teamAlias:alias,id}}},{{ template "pagination_request" }}}}}"`,
+		`{ {{ template "first_page_variables" }} }`,
+		`{ "data": { "account": { "infrastructureResources": { "nodes": [ { {{ template "id1" }}, "aliases": [], "name": "my-big-query", "type": "Database", "owner": { "teamAlias": "platform", {{ template "id2" }} } } ], {{ template "no_pagination_response" }} }}}}`,
+	)
+	client := BestTestClient(t, "projection/infrastructure", testRequest)
+	// Act
+	result, err := ol.ListInfrastructureAs[ol.InfrastructureResourceSummary](client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, result.TotalCount)
+	autopilot.Equals(t, "Database", result.Nodes[0].Schema)
+	autopilot.Equals(t, "platform", result.Nodes[0].Owner.Alias())
+}
@@ -0,0 +1,66 @@
+package opslevel
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"sync"
+)
+
+// RedactedValue replaces the sensitive variables of mutations in journals and dry run reports
+const RedactedValue = "[REDACTED]"
+
+var redactedFields = struct {
+	sync.RWMutex
+	byOperation map[string][]string // "" applies to every operation
+}{byOperation: map[string][]string{
+	"":                          {"apiKey", "password", "token"},
+	"SecretsVaultsSecretCreate": {"value"},
+	"SecretsVaultsSecretUpdate": {"value"},
+}}
+
+// RedactField hides 'field' of the variables of 'operation', e.g. 'SecretsVaultsSecretCreate', at any depth
+// in journals and dry run reports - of every operation when 'operation' is empty
+func RedactField(operation string, field string) {
+	redactedFields.Lock()
+	defer redactedFields.Unlock()
+	if !slices.Contains(redactedFields.byOperation[operation], field) {
+		redactedFields.byOperation[operation] = append(redactedFields.byOperation[operation], field)
+	}
+}
+
+// redactVariables returns a JSON compatible copy of the variables of 'operation' with the sensitive fields replaced by RedactedValue
+func redactVariables(operation string, variables map[string]any) (map[string]any, error) {
+	raw, err := json.Marshal(variables)
+	if err != nil {
+		return nil, err
+	}
+	var output map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&output); err != nil {
+		return nil, err
+	}
+	redactedFields.RLock()
+	fields := append(slices.Clone(redactedFields.byOperation[""]), redactedFields.byOperation[operation]...)
+	redactedFields.RUnlock()
+	redactValue(output, fields)
+	return output, nil
+}
+
+func redactValue(value any, fields []string) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if item != nil && slices.Contains(fields, key) {
+				value[key] = RedactedValue
+				continue
+			}
+			redactValue(item, fields)
+		}
+	case []any:
+		for _, item := range value {
+			redactValue(item, fields)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package opslevel
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MonorepoMarker is a file whose presence marks a directory of a monorepo as the base directory of a service
+type MonorepoMarker struct {
+	File string
+	// ServiceAlias returns the alias of the service from the marker file's content - when nil or
+	// when it returns an empty string the directory name is used
+	ServiceAlias func(content []byte) string
+}
+
+// DefaultMonorepoMarkers finds directories with an opslevel.yml service config
+var DefaultMonorepoMarkers = []MonorepoMarker{
+	{File: "opslevel.yml", ServiceAlias: opslevelConfigServiceAlias},
+	{File: "opslevel.yaml", ServiceAlias: opslevelConfigServiceAlias},
+}
+
+var monorepoSkipDirectories = []string{".git", "node_modules", "vendor"}
+
+func opslevelConfigServiceAlias(content []byte) string {
+	var config struct {
+		Service struct {
+			Name    string   `yaml:"name"`
+			Aliases []string `yaml:"aliases"`
+		} `yaml:"service"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return ""
+	}
+	if len(config.Service.Aliases) > 0 {
+		return config.Service.Aliases[0]
+	}
+	return PredictAlias(config.Service.Name)
+}
+
+// MonorepoServiceDirectory is a directory of a checkout that belongs to the service with Alias
+type MonorepoServiceDirectory struct {
+	BaseDirectory string // Relative to the checkout root using '/' separators, '/' for the root itself
+	Alias         string
+	Marker        string
+}
+
+func normalizeBaseDirectory(directory string) string {
+	directory = strings.Trim(filepath.ToSlash(directory), "/")
+	if directory == "" || directory == "." {
+		return "/"
+	}
+	return directory
+}
+
+// ScanMonorepo walks the checkout at 'root' returning each directory containing one of the markers
+func ScanMonorepo(root string, markers ...MonorepoMarker) ([]MonorepoServiceDirectory, error) {
+	if len(markers) == 0 {
+		markers = DefaultMonorepoMarkers
+	}
+	var output []MonorepoServiceDirectory
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			for _, skip := range monorepoSkipDirectories {
+				if entry.Name() == skip {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+		for _, marker := range markers {
+			if entry.Name() != marker.File {
+				continue
+			}
+			directory, err := filepath.Rel(root, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+			alias := ""
+			if marker.ServiceAlias != nil {
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				alias = marker.ServiceAlias(content)
+			}
+			if alias == "" {
+				alias = filepath.Base(filepath.Dir(path))
+			}
+			output = append(output, MonorepoServiceDirectory{
+				BaseDirectory: normalizeBaseDirectory(directory),
+				Alias:         alias,
+				Marker:        marker.File,
+			})
+			break
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(output, func(i, j int) bool { return output[i].BaseDirectory < output[j].BaseDirectory })
+	return output, nil
+}
+
+type MonorepoLinkPlan struct {
+	Create          []ServiceRepositoryCreateInput
+	Update          []ServiceRepositoryUpdateInput // Services linked to the repository once with a different base directory
+	Unchanged       []ServiceRepository
+	UnknownServices []MonorepoServiceDirectory // Directories whose alias matches no service
+	Errors          []error
+}
+
+// Err joins all the errors encountered while applying the plan
+func (p *MonorepoLinkPlan) Err() error {
+	if len(p.Errors) == 0 {
+		return nil
+	}
+	var messages []string
+	for _, err := range p.Errors {
+		messages = append(messages, err.Error())
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+// monorepoServiceLinks returns the links of 'service' to 'repository'
+func monorepoServiceLinks(repository *Repository, service *Service) []ServiceRepository {
+	var links []ServiceRepository
+	if service.Repositories == nil {
+		return links
+	}
+	for _, edge := range service.Repositories.Edges {
+		if edge.Node.Id != repository.Id {
+			continue
+		}
+		for _, link := range edge.ServiceRepositories {
+			if link.Service.Id == "" || link.Service.Id == service.Id {
+				links = append(links, link)
+			}
+		}
+	}
+	return links
+}
+
+// DiffMonorepoLinks compares the scanned directories with the links of the services, keyed by alias and with
+// their Repositories loaded, to 'repository'. When several directories share a service alias, directories matching
+// an existing link are unchanged, the single link of the service is updated at most once and the rest are created.
+func DiffMonorepoLinks(repository *Repository, directories []MonorepoServiceDirectory, services map[string]*Service) *MonorepoLinkPlan {
+	plan := &MonorepoLinkPlan{}
+	claimed := map[ID]bool{} // Links left unchanged or already updated by a directory
+	var pending []MonorepoServiceDirectory
+	for _, directory := range directories {
+		service, ok := services[directory.Alias]
+		if !ok || service == nil || service.Id == "" {
+			plan.UnknownServices = append(plan.UnknownServices, directory)
+			continue
+		}
+		matched := false
+		for _, link := range monorepoServiceLinks(repository, service) {
+			if normalizeBaseDirectory(link.BaseDirectory) == directory.BaseDirectory {
+				plan.Unchanged = append(plan.Unchanged, link)
+				claimed[link.Id] = true
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			pending = append(pending, directory)
+		}
+	}
+	for _, directory := range pending {
+		service := services[directory.Alias]
+		links := monorepoServiceLinks(repository, service)
+		if len(links) == 1 && !claimed[links[0].Id] {
+			plan.Update = append(plan.Update, ServiceRepositoryUpdateInput{Id: links[0].Id, BaseDirectory: directory.BaseDirectory})
+			claimed[links[0].Id] = true
+			continue
+		}
+		plan.Create = append(plan.Create, ServiceRepositoryCreateInput{
+			Service:       IdentifierInput{Id: service.Id},
+			Repository:    IdentifierInput{Id: repository.Id},
+			BaseDirectory: directory.BaseDirectory,
+			DisplayName:   fmt.Sprintf("%s/%s", repository.Organization, repository.Name),
+		})
+	}
+	return plan
+}
+
+// PlanMonorepoLinks scans the checkout at 'root' and diffs the directories found against each service's repositories
+func (client *Client) PlanMonorepoLinks(repository *Repository, root string, markers ...MonorepoMarker) (*MonorepoLinkPlan, error) {
+	directories, err := ScanMonorepo(root, markers...)
+	if err != nil {
+		return nil, err
+	}
+	resolver := client.NewResolver()
+	for _, directory := range directories {
+		resolver.Want(ResolverKindService, directory.Alias)
+	}
+	if err := resolver.Flush(); err != nil {
+		return nil, err
+	}
+	services := map[string]*Service{}
+	for _, directory := range directories {
+		if _, ok := services[directory.Alias]; ok {
+			continue
+		}
+		// The aliases were looked up by Flush so Resolve only fails for services that do not exist
+		id, err := resolver.Resolve(ResolverKindService, directory.Alias)
+		if err != nil {
+			services[directory.Alias] = nil
+			continue
+		}
+		service := &Service{ServiceId: ServiceId{Id: id, Aliases: []string{directory.Alias}}}
+		if _, err := service.GetRepositories(client, nil); err != nil {
+			return nil, err
+		}
+		services[directory.Alias] = service
+	}
+	return DiffMonorepoLinks(repository, directories, services), nil
+}
+
+// ApplyMonorepoLinks creates and updates the service repositories in the plan, recording failures in its Errors
+func (client *Client) ApplyMonorepoLinks(plan *MonorepoLinkPlan) error {
+	for _, input := range plan.Create {
+		if _, err := client.CreateServiceRepository(input); err != nil {
+			plan.Errors = append(plan.Errors, fmt.Errorf("link service '%s' at '%s': %w", input.Service.Id, input.BaseDirectory, err))
+		}
+	}
+	for _, input := range plan.Update {
+		if _, err := client.UpdateServiceRepository(input); err != nil {
+			plan.Errors = append(plan.Errors, fmt.Errorf("update service repository '%s' to '%s': %w", input.Id, input.BaseDirectory, err))
+		}
+	}
+	return plan.Err()
+}
@@ -0,0 +1,153 @@
+package opslevel_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func writeTestMonorepo(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"opslevel.yml":                    "version: 1\nservice:\n  name: Platform\n",
+		"services/checkout/opslevel.yml":  "version: 1\nservice:\n  name: Checkout API\n  aliases:\n    - checkout\n",
+		"services/payments/opslevel.yaml": "version: 1\nservice:\n  name: Payments\n",
+		"services/search/README.md":       "# Search\n",
+		"services/search/Dockerfile":      "FROM scratch\n",
+		"node_modules/dep/opslevel.yml":   "version: 1\nservice:\n  name: Dependency\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		autopilot.Ok(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		autopilot.Ok(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return root
+}
+
+func TestScanMonorepo(t *testing.T) {
+	// Arrange
+	root := writeTestMonorepo(t)
+	// Act
+	result, err := ol.ScanMonorepo(root)
+	dockerfiles, dockerErr := ol.ScanMonorepo(root, ol.MonorepoMarker{File: "Dockerfile"})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []ol.MonorepoServiceDirectory{
+		{BaseDirectory: "/", Alias: "platform", Marker: "opslevel.yml"},
+		{BaseDirectory: "services/checkout", Alias: "checkout", Marker: "opslevel.yml"},
+		{BaseDirectory: "services/payments", Alias: "payments", Marker: "opslevel.yaml"},
+	}, result)
+	autopilot.Ok(t, dockerErr)
+	autopilot.Equals(t, []ol.MonorepoServiceDirectory{{BaseDirectory: "services/search", Alias: "search", Marker: "Dockerfile"}}, dockerfiles)
+}
+
+func TestDiffMonorepoLinks(t *testing.T) {
+	// Arrange
+	repository := &ol.Repository{Id: id1, Name: "monorepo", Organization: "opslevel"}
+	directories := []ol.MonorepoServiceDirectory{
+		{BaseDirectory: "/", Alias: "platform"},
+		{BaseDirectory: "services/checkout", Alias: "checkout"},
+		{BaseDirectory: "services/payments", Alias: "payments"},
+		{BaseDirectory: "services/search", Alias: "search"},
+	}
+	services := map[string]*ol.Service{
+		"platform": {ServiceId: ol.ServiceId{Id: id2}},
+		"checkout": {ServiceId: ol.ServiceId{Id: id3}, Repositories: &ol.ServiceRepositoryConnection{Edges: []ol.ServiceRepositoryEdge{
+			{Node: ol.RepositoryId{Id: id1}, ServiceRepositories: []ol.ServiceRepository{{Id: "link-1", BaseDirectory: "/services/checkout"}}},
+		}}},
+		"payments": {ServiceId: ol.ServiceId{Id: id4}, Repositories: &ol.ServiceRepositoryConnection{Edges: []ol.ServiceRepositoryEdge{
+			{Node: ol.RepositoryId{Id: id1}, ServiceRepositories: []ol.ServiceRepository{{Id: "link-2", BaseDirectory: "payments"}}},
+		}}},
+	}
+	// Act
+	result := ol.DiffMonorepoLinks(repository, directories, services)
+	// Assert
+	autopilot.Equals(t, []ol.ServiceRepositoryCreateInput{{
+		Service:       ol.IdentifierInput{Id: id2},
+		Repository:    ol.IdentifierInput{Id: id1},
+		BaseDirectory: "/",
+		DisplayName:   "opslevel/monorepo",
+	}}, result.Create)
+	autopilot.Equals(t, ol.ID("link-1"), result.Unchanged[0].Id)
+	autopilot.Equals(t, []ol.ServiceRepositoryUpdateInput{{Id: "link-2", BaseDirectory: "services/payments"}}, result.Update)
+	autopilot.Equals(t, "search", result.UnknownServices[0].Alias)
+}
+
+func TestDiffMonorepoLinksSharedAlias(t *testing.T) {
+	// Arrange
+	repository := &ol.Repository{Id: id1, Name: "monorepo", Organization: "opslevel"}
+	directories := []ol.MonorepoServiceDirectory{
+		{BaseDirectory: "services/checkout", Alias: "checkout"},
+		{BaseDirectory: "services/checkout-worker", Alias: "checkout"},
+		{BaseDirectory: "services/payments", Alias: "payments"},
+		{BaseDirectory: "services/payments-api", Alias: "payments"},
+	}
+	services := map[string]*ol.Service{
+		"checkout": {ServiceId: ol.ServiceId{Id: id3}, Repositories: &ol.ServiceRepositoryConnection{Edges: []ol.ServiceRepositoryEdge{
+			{Node: ol.RepositoryId{Id: id1}, ServiceRepositories: []ol.ServiceRepository{{Id: "link-1", BaseDirectory: "checkout"}}},
+		}}},
+		"payments": {ServiceId: ol.ServiceId{Id: id4}, Repositories: &ol.ServiceRepositoryConnection{Edges: []ol.ServiceRepositoryEdge{
+			{Node: ol.RepositoryId{Id: id1}, ServiceRepositories: []ol.ServiceRepository{{Id: "link-2", BaseDirectory: "services/payments-api"}}},
+		}}},
+	}
+	// Act
+	result := ol.DiffMonorepoLinks(repository, directories, services)
+	// Assert
+	autopilot.Equals(t, []ol.ServiceRepositoryUpdateInput{{Id: "link-1", BaseDirectory: "services/checkout"}}, result.Update)
+	autopilot.Equals(t, ol.ID("link-2"), result.Unchanged[0].Id)
+	autopilot.Equals(t, 2, len(result.Create))
+	autopilot.Equals(t, "services/checkout-worker", result.Create[0].BaseDirectory)
+	autopilot.Equals(t, "services/payments", result.Create[1].BaseDirectory)
+}
+
+func TestPlanMonorepoLinks(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"query IdentifierResolve($r0:String!$r1:String!$r2:String!){account{r0: service(alias: $r0){id},r1: service(alias: $r1){id},r2: service(alias: $r2){id}}}"`,
+		`{ "r0": "platform", "r1": "checkout", "r2": "payments" }`,
+		`{ "data": { "account": { "r0": { {{ template "id2" }} }, "r1": { {{ template "id3" }} }, "r2": null }}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"query ServiceRepositoriesList($after:String!$first:Int!$service:ID!){account{service(id: $service){repos(after: $after, first: $first){edges{node{id,defaultAlias},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount}}}}"`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id2_string" }}" }`,
+		`{ "data": { "account": { "service": { "repos": { "edges": [], {{ template "no_pagination_response" }}, "totalCount": 0 }}}}}`,
+	)
+	testRequestThree := NewTestRequest(
+		`"query ServiceRepositoriesList($after:String!$first:Int!$service:ID!){account{service(id: $service){repos(after: $after, first: $first){edges{node{id,defaultAlias},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount}}}}"`,
+		`{ {{ template "first_page_variables" }}, "service": "{{ template "id3_string" }}" }`,
+		`{ "data": { "account": { "service": { "repos": { "edges": [ { "node": { {{ template "id1" }} }, "serviceRepositories": [ { "id": "link-1", "baseDirectory": "services/checkout" } ] } ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}}`,
+	)
+	client := BestTestClient(t, "repository/plan_monorepo", testRequestOne, testRequestTwo, testRequestThree)
+	repository := &ol.Repository{Id: id1, Name: "monorepo", Organization: "opslevel"}
+	// Act
+	result, err := client.PlanMonorepoLinks(repository, writeTestMonorepo(t))
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result.Create))
+	autopilot.Equals(t, id2, result.Create[0].Service.Id)
+	autopilot.Equals(t, ol.ID("link-1"), result.Unchanged[0].Id)
+	autopilot.Equals(t, "payments", result.UnknownServices[0].Alias)
+}
+
+func TestApplyMonorepoLinks(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation ServiceRepositoryCreate($input:ServiceRepositoryCreateInput!){serviceRepositoryCreate(input: $input){serviceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}},errors{message,path}}}"`,
+		`{"input": { "service": { {{ template "id2" }} }, "repository": { {{ template "id1" }} }, "baseDirectory": "services/checkout", "displayName": "opslevel/monorepo" }}`,
+		`{"data": { "serviceRepositoryCreate": { "serviceRepository": { "baseDirectory": "services/checkout", "displayName": "opslevel/monorepo", {{ template "id3" }} }, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "repository/monorepo_apply", testRequest)
+	plan := &ol.MonorepoLinkPlan{Create: []ol.ServiceRepositoryCreateInput{{
+		Service:       ol.IdentifierInput{Id: id2},
+		Repository:    ol.IdentifierInput{Id: id1},
+		BaseDirectory: "services/checkout",
+		DisplayName:   "opslevel/monorepo",
+	}}}
+	// Act
+	err := client.ApplyMonorepoLinks(plan)
+	// Assert
+	autopilot.Ok(t, err)
+}
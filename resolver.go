@@ -0,0 +1,184 @@
+package opslevel
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// resolverChunkSize is the number of lookups a Resolver sends per request
+const resolverChunkSize = 100
+
+// ResolverKind is a type of entity a Resolver can look up by alias
+type ResolverKind string
+
+const (
+	ResolverKindService        ResolverKind = "service"
+	ResolverKindTeam           ResolverKind = "team"
+	ResolverKindRepository     ResolverKind = "repository"
+	ResolverKindUser           ResolverKind = "user" // Looked up by email
+	ResolverKindInfrastructure ResolverKind = "infrastructureResource"
+)
+
+type resolverKey struct {
+	kind  ResolverKind
+	alias string
+}
+
+// Resolver converts aliases to IDs. Aliases queued with Want are deduplicated and looked up together as aliased
+// fields of a single query the next time Flush or Resolve is called, and the results are cached for the
+// lifetime of the Resolver - an empty ID is cached for aliases that were not found.
+type Resolver struct {
+	client   *Client
+	mutex    sync.Mutex
+	resolved map[resolverKey]ID
+	pending  []resolverKey
+}
+
+func (client *Client) NewResolver() *Resolver {
+	return &Resolver{
+		client:   client,
+		resolved: map[resolverKey]ID{},
+	}
+}
+
+// Set caches the ID of an alias that is already known, e.g. from the Cacher
+func (r *Resolver) Set(kind ResolverKind, alias string, id ID) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.resolved[resolverKey{kind: kind, alias: alias}] = id
+}
+
+// Want queues aliases to be looked up by the next Flush - IDs, cached and already queued aliases are skipped
+func (r *Resolver) Want(kind ResolverKind, aliases ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.want(kind, aliases...)
+}
+
+func (r *Resolver) want(kind ResolverKind, aliases ...string) {
+	for _, alias := range aliases {
+		key := resolverKey{kind: kind, alias: alias}
+		if alias == "" || IsID(alias) {
+			continue
+		}
+		if _, ok := r.resolved[key]; ok {
+			continue
+		}
+		queued := false
+		for _, pending := range r.pending {
+			if pending == key {
+				queued = true
+				break
+			}
+		}
+		if !queued {
+			r.pending = append(r.pending, key)
+		}
+	}
+}
+
+// Flush looks up all the queued aliases
+func (r *Resolver) Flush() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.flush()
+}
+
+func (r *Resolver) flush() error {
+	for len(r.pending) > 0 {
+		chunk := r.pending[:min(resolverChunkSize, len(r.pending))]
+		if err := r.query(chunk); err != nil {
+			return err
+		}
+		r.pending = r.pending[len(chunk):]
+	}
+	r.pending = nil
+	return nil
+}
+
+// query looks up the keys as fields aliased 'r<index>' of the account, e.g. 'r0: service(alias: $r0){id}'
+func (r *Resolver) query(keys []resolverKey) error {
+	type node struct {
+		Id ID
+	}
+	fields := make([]reflect.StructField, len(keys))
+	v := PayloadVariables{}
+	for i, key := range keys {
+		name := fmt.Sprintf("r%d", i)
+		argument := "alias"
+		switch key.kind {
+		case ResolverKindService, ResolverKindTeam, ResolverKindRepository:
+			v[name] = key.alias
+		case ResolverKindUser:
+			argument = "input"
+			v[name] = UserIdentifierInput{Email: key.alias}
+		case ResolverKindInfrastructure:
+			argument = "input"
+			v[name] = IdentifierInput{Alias: key.alias}
+		default:
+			return fmt.Errorf("unsupported resolver kind '%s'", key.kind)
+		}
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("R%d", i),
+			Type: reflect.TypeOf(node{}),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"%s: %s(%s: $%s)"`, name, key.kind, argument, name)),
+		}
+	}
+	q := reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Account",
+		Type: reflect.StructOf(fields),
+	}}))
+	if err := r.client.Query(q.Interface(), v, WithName("IdentifierResolve")); err != nil {
+		return err
+	}
+	account := q.Elem().Field(0)
+	for i, key := range keys {
+		r.resolved[key] = account.Field(i).Interface().(node).Id
+	}
+	return nil
+}
+
+// Resolve returns the ID of the entity with 'alias', looking it up along with any queued aliases if it is not cached.
+// IDs are returned as is.
+func (r *Resolver) Resolve(kind ResolverKind, alias string) (ID, error) {
+	if IsID(alias) {
+		return ID(alias), nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	key := resolverKey{kind: kind, alias: alias}
+	if _, ok := r.resolved[key]; !ok {
+		r.want(kind, alias)
+		if err := r.flush(); err != nil {
+			return "", err
+		}
+	}
+	if id := r.resolved[key]; id != "" {
+		return id, nil
+	}
+	return "", fmt.Errorf("%s with alias '%s' not found", kind, alias)
+}
+
+// ResolveAll returns the IDs of the entities with 'aliases', keyed by alias, looking them up in as few queries as possible
+func (r *Resolver) ResolveAll(kind ResolverKind, aliases ...string) (map[string]ID, error) {
+	r.Want(kind, aliases...)
+	output := map[string]ID{}
+	for _, alias := range aliases {
+		id, err := r.Resolve(kind, alias)
+		if err != nil {
+			return output, err
+		}
+		output[alias] = id
+	}
+	return output, nil
+}
+
+// Identifier returns an IdentifierInput with the ID of the entity with 'identifier', an alias or ID
+func (r *Resolver) Identifier(kind ResolverKind, identifier string) (*IdentifierInput, error) {
+	id, err := r.Resolve(kind, identifier)
+	if err != nil {
+		return nil, err
+	}
+	return &IdentifierInput{Id: id}, nil
+}
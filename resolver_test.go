@@ -0,0 +1,60 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+func TestResolverDeduplicatesAndCaches(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query IdentifierResolve($r0:String!$r1:String!$r2:UserIdentifierInput!$r3:IdentifierInput!){account{r0: service(alias: $r0){id},r1: team(alias: $r1){id},r2: user(input: $r2){id},r3: infrastructureResource(input: $r3){id}}}"`,
+		`{"r0": "foo", "r1": "platform", "r2": { "email": "kyle@opslevel.com" }, "r3": { "alias": "missing" }}`,
+		`{"data": { "account": { "r0": { {{ template "id1" }} }, "r1": { {{ template "id2" }} }, "r2": { {{ template "id3" }} }, "r3": null }}}`,
+	)
+	client := BestTestClient(t, "resolver/batch", testRequest)
+	resolver := client.NewResolver()
+	// Act
+	resolver.Want(ol.ResolverKindService, "foo", "foo", string(id4))
+	resolver.Want(ol.ResolverKindTeam, "platform")
+	resolver.Want(ol.ResolverKindUser, "kyle@opslevel.com")
+	resolver.Want(ol.ResolverKindInfrastructure, "missing")
+	service, err := resolver.Resolve(ol.ResolverKindService, "foo")
+	autopilot.Ok(t, err)
+	team, err := resolver.Identifier(ol.ResolverKindTeam, "platform")
+	autopilot.Ok(t, err)
+	user, err := resolver.Resolve(ol.ResolverKindUser, "kyle@opslevel.com")
+	autopilot.Ok(t, err)
+	byId, err := resolver.Resolve(ol.ResolverKindService, string(id4))
+	autopilot.Ok(t, err)
+	_, missingErr := resolver.Resolve(ol.ResolverKindInfrastructure, "missing")
+	// Assert
+	autopilot.Equals(t, id1, service)
+	autopilot.Equals(t, id2, team.Id)
+	autopilot.Equals(t, id3, user)
+	autopilot.Equals(t, id4, byId)
+	autopilot.Equals(t, "infrastructureResource with alias 'missing' not found", missingErr.Error())
+}
+
+func TestResolverResolveAll(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query IdentifierResolve($r0:String!$r1:String!){account{r0: repository(alias: $r0){id},r1: repository(alias: $r1){id}}}"`,
+		`{"r0": "github.com:opslevel/a", "r1": "github.com:opslevel/b"}`,
+		`{"data": { "account": { "r0": { {{ template "id1" }} }, "r1": { {{ template "id2" }} } }}}`,
+	)
+	client := BestTestClient(t, "resolver/all", testRequest)
+	resolver := client.NewResolver()
+	resolver.Set(ol.ResolverKindRepository, "github.com:opslevel/c", id3)
+	// Act
+	result, err := resolver.ResolveAll(ol.ResolverKindRepository, "github.com:opslevel/a", "github.com:opslevel/b", "github.com:opslevel/c", "github.com:opslevel/a")
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, map[string]ol.ID{
+		"github.com:opslevel/a": id1,
+		"github.com:opslevel/b": id2,
+		"github.com:opslevel/c": id3,
+	}, result)
+}
@@ -2,6 +2,7 @@ package opslevel
 
 import (
 	"fmt"
+	"strings"
 )
 
 type ScorecardId struct {
@@ -20,6 +21,10 @@ type Scorecard struct {
 	PassingChecks               int         `graphql:"passingChecks"`
 	ServiceCount                int         `graphql:"serviceCount"`
 	ChecksCount                 int         `graphql:"totalChecks"`
+
+	Categories    *CategoryConnection              `graphql:"-"`
+	Checks        *CheckConnection                 `graphql:"-"`
+	ServiceScores *ScorecardServiceScoreConnection `graphql:"-"`
 }
 
 type ScorecardConnection struct {
@@ -28,6 +33,19 @@ type ScorecardConnection struct {
 	TotalCount int         `graphql:"totalCount"`
 }
 
+// ScorecardServiceScore is a single service's result within a scorecard
+type ScorecardServiceScore struct {
+	Service       ServiceId `graphql:"service"`
+	PassingChecks int       `graphql:"passingChecks"`
+	TotalChecks   int       `graphql:"totalChecks"`
+}
+
+type ScorecardServiceScoreConnection struct {
+	Nodes      []ScorecardServiceScore `graphql:"nodes"`
+	PageInfo   PageInfo                `graphql:"pageInfo"`
+	TotalCount int                     `graphql:"totalCount"`
+}
+
 type ScorecardInput struct {
 	AffectsOverallServiceLevels *bool   `graphql:"affectsOverallServiceLevels" json:"affectsOverallServiceLevels,omitempty"`
 	Name                        string  `graphql:"name" json:"name"`
@@ -36,6 +54,155 @@ type ScorecardInput struct {
 	FilterId                    *ID     `graphql:"filterId" json:"filterId,omitempty"`
 }
 
+// PassingCheckFraction returns the fraction of the scorecard's checks that are passing
+func (s *Scorecard) PassingCheckFraction() float64 {
+	if s.ChecksCount == 0 {
+		return 0
+	}
+	return float64(s.PassingChecks) / float64(s.ChecksCount)
+}
+
+// PassingCheckFraction returns the fraction of the scorecard's checks the service is passing
+func (s *ScorecardServiceScore) PassingCheckFraction() float64 {
+	if s.TotalChecks == 0 {
+		return 0
+	}
+	return float64(s.PassingChecks) / float64(s.TotalChecks)
+}
+
+func (s *Scorecard) ListCategories(client *Client, variables *PayloadVariables) (*CategoryConnection, error) {
+	var q struct {
+		Account struct {
+			Scorecard struct {
+				Categories CategoryConnection `graphql:"categories(after: $after, first: $first)"`
+			} `graphql:"scorecard(input: $scorecard)"`
+		}
+	}
+	if s.Id == "" {
+		return nil, fmt.Errorf("Unable to get Categories, invalid scorecard id: '%s'", s.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["scorecard"] = *NewIdentifier(string(s.Id))
+	if err := client.Query(&q, *variables, WithName("ScorecardCategoryList")); err != nil {
+		return nil, err
+	}
+	if s.Categories == nil {
+		s.Categories = &CategoryConnection{}
+	}
+	s.Categories.Nodes = append(s.Categories.Nodes, q.Account.Scorecard.Categories.Nodes...)
+	s.Categories.PageInfo = q.Account.Scorecard.Categories.PageInfo
+	s.Categories.TotalCount += q.Account.Scorecard.Categories.TotalCount
+	for s.Categories.PageInfo.HasNextPage {
+		(*variables)["after"] = s.Categories.PageInfo.End
+		_, err := s.ListCategories(client, variables)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.Categories, nil
+}
+
+func (s *Scorecard) ListChecks(client *Client, variables *PayloadVariables) (*CheckConnection, error) {
+	var q struct {
+		Account struct {
+			Scorecard struct {
+				Checks CheckConnection `graphql:"checks(after: $after, first: $first)"`
+			} `graphql:"scorecard(input: $scorecard)"`
+		}
+	}
+	if s.Id == "" {
+		return nil, fmt.Errorf("Unable to get Checks, invalid scorecard id: '%s'", s.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["scorecard"] = *NewIdentifier(string(s.Id))
+	if err := client.Query(&q, *variables, WithName("ScorecardCheckList")); err != nil {
+		return nil, err
+	}
+	if s.Checks == nil {
+		s.Checks = &CheckConnection{}
+	}
+	s.Checks.Nodes = append(s.Checks.Nodes, q.Account.Scorecard.Checks.Nodes...)
+	s.Checks.PageInfo = q.Account.Scorecard.Checks.PageInfo
+	s.Checks.TotalCount += q.Account.Scorecard.Checks.TotalCount
+	for s.Checks.PageInfo.HasNextPage {
+		(*variables)["after"] = s.Checks.PageInfo.End
+		_, err := s.ListChecks(client, variables)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.Checks, nil
+}
+
+// ListServiceScores returns the score of each service the scorecard applies to ordered by 'sortBy'
+func (s *Scorecard) ListServiceScores(client *Client, sortBy ScorecardSortEnum, variables *PayloadVariables) (*ScorecardServiceScoreConnection, error) {
+	var q struct {
+		Account struct {
+			Scorecard struct {
+				ServiceScores ScorecardServiceScoreConnection `graphql:"serviceScores(sortBy: $sortBy, after: $after, first: $first)"`
+			} `graphql:"scorecard(input: $scorecard)"`
+		}
+	}
+	if s.Id == "" {
+		return nil, fmt.Errorf("Unable to get Service Scores, invalid scorecard id: '%s'", s.Id)
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["scorecard"] = *NewIdentifier(string(s.Id))
+	(*variables)["sortBy"] = sortBy
+	if err := client.Query(&q, *variables, WithName("ScorecardServiceScoreList")); err != nil {
+		return nil, err
+	}
+	if s.ServiceScores == nil {
+		s.ServiceScores = &ScorecardServiceScoreConnection{}
+	}
+	s.ServiceScores.Nodes = append(s.ServiceScores.Nodes, q.Account.Scorecard.ServiceScores.Nodes...)
+	s.ServiceScores.PageInfo = q.Account.Scorecard.ServiceScores.PageInfo
+	s.ServiceScores.TotalCount += q.Account.Scorecard.ServiceScores.TotalCount
+	for s.ServiceScores.PageInfo.HasNextPage {
+		(*variables)["after"] = s.ServiceScores.PageInfo.End
+		_, err := s.ListServiceScores(client, sortBy, variables)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.ServiceScores, nil
+}
+
+// CreateScorecardCheck creates the check inside one of the scorecard's categories instead of the rubric.
+// The 'category' can be the category's id or name, and may be left empty when the scorecard has a single category.
+func (client *Client) CreateScorecardCheck(scorecard string, category string, input any) (*Check, error) {
+	provider, ok := input.(CheckCreateInputProvider)
+	if !ok {
+		return nil, fmt.Errorf("unknown input type %T", input)
+	}
+	sc, err := client.GetScorecard(scorecard)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := sc.ListCategories(client, nil)
+	if err != nil {
+		return nil, err
+	}
+	var match *Category
+	for i, item := range categories.Nodes {
+		if (category == "" && len(categories.Nodes) == 1) || string(item.Id) == category || strings.EqualFold(item.Name, category) {
+			match = &categories.Nodes[i]
+			break
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("category '%s' not found on scorecard '%s'", category, scorecard)
+	}
+	provider.GetCheckCreateInput().Category = match.Id
+	return client.CreateCheck(input)
+}
+
 func (client *Client) CreateScorecard(input ScorecardInput) (*Scorecard, error) {
 	var m struct {
 		Payload struct {
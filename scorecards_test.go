@@ -164,3 +164,98 @@ func TestListScorecards(t *testing.T) {
 	autopilot.Equals(t, *newOwnerId, result[2].Owner.Id())
 	autopilot.Equals(t, 33, result[2].ServiceCount)
 }
+
+func TestListScorecardCategories(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"{{ template "scorecard_category_list_query" }}"`,
+		`{ {{ template "first_page_variables" }}, "scorecard": {"id": "Z2lkOi8vMTIzNDU2Nzg5MTAK"} }`,
+		`{ "data": { "account": { "scorecard": { "categories": { "nodes": [ {{ template "category_1" }} ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1 }}}}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"{{ template "scorecard_category_list_query" }}"`,
+		`{ {{ template "second_page_variables" }}, "scorecard": {"id": "Z2lkOi8vMTIzNDU2Nzg5MTAK"} }`,
+		`{ "data": { "account": { "scorecard": { "categories": { "nodes": [ {{ template "category_2" }} ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}}`,
+	)
+	requests := []TestRequest{testRequestOne, testRequestTwo}
+
+	client := BestTestClient(t, "scorecards/list_categories", requests...)
+	scorecard := ol.Scorecard{ScorecardId: ol.ScorecardId{Id: ol.ID(scorecardId)}}
+	// Act
+	response, err := scorecard.ListCategories(client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	autopilot.Equals(t, id1, response.Nodes[0].Id)
+	autopilot.Equals(t, id2, scorecard.Categories.Nodes[1].Id)
+}
+
+func TestListScorecardChecks(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"{{ template "scorecard_check_list_query" }}"`,
+		`{ {{ template "first_page_variables" }}, "scorecard": {"id": "Z2lkOi8vMTIzNDU2Nzg5MTAK"} }`,
+		`{ "data": { "account": { "scorecard": { "checks": { "nodes": [ { {{ template "common_check_response" }} }, { {{ template "metrics_tool_check" }} } ], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}}`,
+	)
+
+	client := BestTestClient(t, "scorecards/list_checks", testRequest)
+	scorecard := ol.Scorecard{ScorecardId: ol.ScorecardId{Id: ol.ID(scorecardId)}}
+	// Act
+	response, err := scorecard.ListChecks(client, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	autopilot.Equals(t, "Repository Integrated", response.Nodes[0].Name)
+}
+
+func TestListScorecardServiceScores(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"{{ template "scorecard_service_score_list_query" }}"`,
+		`{ {{ template "first_page_variables" }}, "scorecard": {"id": "Z2lkOi8vMTIzNDU2Nzg5MTAK"}, "sortBy": "passingCheckFraction_DESC" }`,
+		`{ "data": { "account": { "scorecard": { "serviceScores": { "nodes": [
+			{ "service": { {{ template "id1" }}, "aliases": ["api"] }, "passingChecks": 3, "totalChecks": 4 },
+			{ "service": { {{ template "id2" }}, "aliases": ["web"] }, "passingChecks": 0, "totalChecks": 0 }
+		], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}}`,
+	)
+
+	client := BestTestClient(t, "scorecards/list_service_scores", testRequest)
+	scorecard := ol.Scorecard{ScorecardId: ol.ScorecardId{Id: ol.ID(scorecardId)}}
+	// Act
+	response, err := scorecard.ListServiceScores(client, ol.ScorecardSortEnumPassingcheckfractionDesc, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, response.TotalCount)
+	autopilot.Equals(t, "api", response.Nodes[0].Service.Aliases[0])
+	autopilot.Equals(t, 0.75, response.Nodes[0].PassingCheckFraction())
+	autopilot.Equals(t, 0.0, response.Nodes[1].PassingCheckFraction())
+}
+
+func TestCreateScorecardCheck(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`{{ template "scorecard_get_request" }}`,
+		`{{ template "scorecard_get_request_vars" }}`,
+		`{{ template "scorecard_get_response" }}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"{{ template "scorecard_category_list_query" }}"`,
+		`{ {{ template "first_page_variables" }}, "scorecard": {"id": "Z2lkOi8vMTIzNDU2Nzg5MTAK"} }`,
+		`{ "data": { "account": { "scorecard": { "categories": { "nodes": [ {{ template "category_1" }}, {{ template "category_2" }} ], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}}`,
+	)
+	testRequestThree := NewTestRequest(
+		`"mutation CheckManualCreate($input:CheckManualCreateInput!){checkManualCreate(input: $input){check{category{id,name},description,enabled,enableOn,filter{connective,htmlUrl,id,name,predicates{key,keyData,type,value,caseSensitive}},id,level{alias,description,id,index,name},name,notes: rawNotes,owner{... on Team{alias,id}},type,... on AlertSourceUsageCheck{alertSourceNamePredicate{type,value},alertSourceType},... on CustomEventCheck{integration{id,name,type},passPending,resultMessage,serviceSelector,successCondition},... on HasRecentDeployCheck{days},... on ManualCheck{updateFrequency{startingDate,frequencyTimeScale,frequencyValue},updateRequiresComment},... on RepositoryFileCheck{directorySearch,filePaths,fileContentsPredicate{type,value},useAbsoluteRoot},... on RepositoryGrepCheck{directorySearch,filePaths,fileContentsPredicate{type,value}},... on RepositorySearchCheck{fileExtensions,fileContentsPredicate{type,value}},... on ServiceOwnershipCheck{requireContactMethod,contactMethod,tagKey,tagPredicate{type,value}},... on ServicePropertyCheck{serviceProperty,propertyValuePredicate{type,value}},... on TagDefinedCheck{tagKey,tagPredicate{type,value}},... on ToolUsageCheck{toolCategory,toolNamePredicate{type,value},toolUrlPredicate{type,value},environmentPredicate{type,value}},... on HasDocumentationCheck{documentType,documentSubtype}},errors{message,path}}}"`,
+		`{ "input": { "name": "Hello World", "enabled": true, "categoryId": "{{ template "id2_string" }}", "levelId": "Z2lkOi8vb3BzbGV2ZWwvTGV2ZWwvMzE3", "notes": "Hello World Check", "updateRequiresComment": false }}`,
+		`{ "data": { "checkManualCreate": { "check": { {{ template "common_check_response" }}, "category": {{ template "category_2" }} }, "errors": [] }}}`,
+	)
+	requests := []TestRequest{testRequestOne, testRequestTwo, testRequestThree}
+
+	client := BestTestClient(t, "scorecards/create_check", requests...)
+	// Act
+	result, err := client.CreateScorecardCheck(scorecardId, "example 2", &ol.CheckManualCreateInput{
+		CheckCreateInput: checkCreateInput,
+	})
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id2, result.Category.Id)
+}
@@ -0,0 +1,217 @@
+package opslevel
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// errServiceQueryStop ends ServiceQuery.Each early without an error
+var errServiceQueryStop = errors.New("stop")
+
+// ServiceQuery combines service filters into a single paginated request. A criteria with one value is sent to the
+// API - repeating a criteria, or passing several values, ORs them and is filtered client side instead.
+//
+//	services, err := client.ServiceQuery().WithOwner("platform").WithTier("tier_1", "tier_2").SortBy(ServiceSortEnumNameAsc).List()
+type ServiceQuery struct {
+	client *Client
+
+	framework []string
+	language  []string
+	lifecycle []string
+	owner     []string
+	product   []string
+	tier      []string
+	tags      []TagArgs
+	sortBy    *ServiceSortEnum
+
+	filters []func(*Service) bool
+	limit   int
+}
+
+func (client *Client) ServiceQuery() *ServiceQuery {
+	return &ServiceQuery{client: client}
+}
+
+// serviceQueryCriteria is a criteria's values and the service field they are compared to
+type serviceQueryCriteria struct {
+	values []string
+	get    func(*Service) string
+}
+
+func (q *ServiceQuery) criteria() []serviceQueryCriteria {
+	return []serviceQueryCriteria{
+		{q.framework, func(s *Service) string { return s.Framework }},
+		{q.language, func(s *Service) string { return s.Language }},
+		{q.lifecycle, func(s *Service) string { return s.Lifecycle.Alias }},
+		{q.owner, func(s *Service) string { return s.Owner.Alias }},
+		{q.product, func(s *Service) string { return s.Product }},
+		{q.tier, func(s *Service) string { return s.Tier.Alias }},
+	}
+}
+
+// serviceQueryArgument returns the API argument of a criteria, nil when it is unused or has to be filtered client side
+func serviceQueryArgument[T any](values []T) *T {
+	if len(values) != 1 {
+		return nil
+	}
+	return &values[0]
+}
+
+func (q *ServiceQuery) WithFramework(frameworks ...string) *ServiceQuery {
+	q.framework = append(q.framework, frameworks...)
+	return q
+}
+
+func (q *ServiceQuery) WithLanguage(languages ...string) *ServiceQuery {
+	q.language = append(q.language, languages...)
+	return q
+}
+
+func (q *ServiceQuery) WithLifecycle(aliases ...string) *ServiceQuery {
+	q.lifecycle = append(q.lifecycle, aliases...)
+	return q
+}
+
+func (q *ServiceQuery) WithOwner(aliases ...string) *ServiceQuery {
+	q.owner = append(q.owner, aliases...)
+	return q
+}
+
+func (q *ServiceQuery) WithProduct(products ...string) *ServiceQuery {
+	q.product = append(q.product, products...)
+	return q
+}
+
+func (q *ServiceQuery) WithTier(aliases ...string) *ServiceQuery {
+	q.tier = append(q.tier, aliases...)
+	return q
+}
+
+// WithTag matches services with the tag, in the form 'key' or 'key:value'
+func (q *ServiceQuery) WithTag(tag string) *ServiceQuery {
+	q.tags = append(q.tags, NewTagArgs(tag))
+	return q
+}
+
+func hasTag(service *Service, args TagArgs) bool {
+	if service.Tags == nil {
+		return false
+	}
+	for _, item := range service.Tags.Nodes {
+		if item.Key == args.Key && (args.Value == "" || item.Value == args.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithName matches services whose name contains 'text' ignoring case - filtered client side
+func (q *ServiceQuery) WithName(text string) *ServiceQuery {
+	text = strings.ToLower(text)
+	return q.Where(func(s *Service) bool { return strings.Contains(strings.ToLower(s.Name), text) })
+}
+
+// Where adds a client side filter
+func (q *ServiceQuery) Where(filter func(*Service) bool) *ServiceQuery {
+	q.filters = append(q.filters, filter)
+	return q
+}
+
+func (q *ServiceQuery) SortBy(sortBy ServiceSortEnum) *ServiceQuery {
+	q.sortBy = &sortBy
+	return q
+}
+
+// Limit stops the query after 'limit' matching services - 0 is unlimited
+func (q *ServiceQuery) Limit(limit int) *ServiceQuery {
+	q.limit = limit
+	return q
+}
+
+func (q *ServiceQuery) matches(service *Service) bool {
+	for _, criteria := range q.criteria() {
+		if len(criteria.values) > 1 && !slices.Contains(criteria.values, criteria.get(service)) {
+			return false
+		}
+	}
+	if len(q.tags) > 1 && !slices.ContainsFunc(q.tags, func(args TagArgs) bool { return hasTag(service, args) }) {
+		return false
+	}
+	for _, filter := range q.filters {
+		if !filter(service) {
+			return false
+		}
+	}
+	return true
+}
+
+// Each streams the matching services to 'fn' a page at a time, stopping at the first error 'fn' returns
+func (q *ServiceQuery) Each(fn func(*Service) error) error {
+	var r struct {
+		Account struct {
+			Services ServiceConnection `graphql:"services(framework: $framework, language: $language, lifecycleAlias: $lifecycle, ownerAlias: $owner, product: $product, tag: $tag, tierAlias: $tier, sortBy: $sortBy, after: $after, first: $first)"`
+		}
+	}
+	variables := q.client.InitialPageVariables()
+	variables["framework"] = serviceQueryArgument(q.framework)
+	variables["language"] = serviceQueryArgument(q.language)
+	variables["lifecycle"] = serviceQueryArgument(q.lifecycle)
+	variables["owner"] = serviceQueryArgument(q.owner)
+	variables["product"] = serviceQueryArgument(q.product)
+	variables["tag"] = serviceQueryArgument(q.tags)
+	variables["tier"] = serviceQueryArgument(q.tier)
+	variables["sortBy"] = q.sortBy
+
+	count := 0
+	for {
+		if err := q.client.Query(&r, variables, WithName("ServiceQuery")); err != nil {
+			return err
+		}
+		for i := range r.Account.Services.Nodes {
+			node := &r.Account.Services.Nodes[i]
+			if err := node.Hydrate(q.client); err != nil {
+				return err
+			}
+			if !q.matches(node) {
+				continue
+			}
+			if err := fn(node); err != nil {
+				if errors.Is(err, errServiceQueryStop) {
+					return nil
+				}
+				return err
+			}
+			count++
+			if q.limit > 0 && count >= q.limit {
+				return nil
+			}
+		}
+		if !r.Account.Services.PageInfo.HasNextPage {
+			return nil
+		}
+		variables["after"] = r.Account.Services.PageInfo.End
+		r.Account.Services = ServiceConnection{}
+	}
+}
+
+// List returns all the matching services
+func (q *ServiceQuery) List() ([]Service, error) {
+	var output []Service
+	err := q.Each(func(service *Service) error {
+		output = append(output, *service)
+		return nil
+	})
+	return output, err
+}
+
+// First returns the first matching service or nil if there is none
+func (q *ServiceQuery) First() (*Service, error) {
+	var output *Service
+	err := q.Each(func(service *Service) error {
+		found := *service
+		output = &found
+		return errServiceQueryStop
+	})
+	return output, err
+}
@@ -0,0 +1,91 @@
+package opslevel_test
+
+import (
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+const serviceQueryRequest = `"query ServiceQuery($after:String!$first:Int!$framework:String$language:String$lifecycle:String$owner:String$product:String$sortBy:ServiceSortEnum$tag:TagArgs$tier:String){account{services(framework: $framework, language: $language, lifecycleAlias: $lifecycle, ownerAlias: $owner, product: $product, tag: $tag, tierAlias: $tier, sortBy: $sortBy, after: $after, first: $first){nodes{apiDocumentPath,description,framework,htmlUrl,id,aliases,language,lifecycle{alias,description,id,index,name},managedAliases,name,owner{alias,id},preferredApiDocument{id,htmlUrl,source{... on ApiDocIntegration{id,name,type},... on ServiceRepository{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},timestamps{createdAt,updatedAt}},preferredApiDocumentSource,product,repos{edges{node{id,defaultAlias},serviceRepositories{baseDirectory,displayName,id,repository{id,defaultAlias},service{id,aliases}}},{{ template "pagination_request" }},totalCount},tags{nodes{id,key,value},{{ template "pagination_request" }},totalCount},tier{alias,description,id,index,name},timestamps{createdAt,updatedAt},tools{nodes{category,categoryAlias,displayName,environment,id,url,service{id,aliases}},{{ template "pagination_request" }},totalCount}},{{ template "pagination_request" }},totalCount}}}"`
+
+func TestServiceQueryList(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		serviceQueryRequest,
+		`{ {{ template "first_page_variables" }}, "framework": null, "language": null, "lifecycle": null, "owner": "platform", "product": null, "sortBy": "name_ASC", "tag": { "key": "env", "value": "prod" }, "tier": null }`,
+		`{ "data": { "account": { "services": { "nodes": [ {{ template "service_1" }} ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		serviceQueryRequest,
+		`{ {{ template "second_page_variables" }}, "framework": null, "language": null, "lifecycle": null, "owner": "platform", "product": null, "sortBy": "name_ASC", "tag": { "key": "env", "value": "prod" }, "tier": null }`,
+		`{ "data": { "account": { "services": { "nodes": [ {{ template "service_2" }} ], {{ template "pagination_second_pageInfo_response" }}, "totalCount": 1 }}}}`,
+	)
+	client := BestTestClient(t, "service/query_list", testRequestOne, testRequestTwo)
+	// Act
+	result, err := client.ServiceQuery().
+		WithOwner("platform").
+		WithTag("env:prod").
+		WithName("BA").
+		SortBy(ol.ServiceSortEnumNameAsc).
+		List()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result))
+	autopilot.Equals(t, "Bar", result[0].Name)
+}
+
+func TestServiceQueryFirst(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		serviceQueryRequest,
+		`{ {{ template "first_page_variables" }}, "framework": null, "language": null, "lifecycle": null, "owner": null, "product": null, "sortBy": null, "tag": null, "tier": "tier_1" }`,
+		`{ "data": { "account": { "services": { "nodes": [ {{ template "service_1" }}, {{ template "service_2" }} ], {{ template "pagination_initial_pageInfo_response" }}, "totalCount": 2 }}}}`,
+	)
+	client := BestTestClient(t, "service/query_first", testRequest)
+	// Act
+	result, err := client.ServiceQuery().WithTier("tier_1").First()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "Foo", result.Name)
+}
+
+const serviceQueryTieredNodes = `[
+  { {{ template "id1" }}, "name": "Foo", "tier": { "alias": "tier_1" } },
+  { {{ template "id2" }}, "name": "Bar", "tier": { "alias": "tier_3" } },
+  { {{ template "id3" }}, "name": "Baz", "tier": { "alias": "tier_2" } }
+]`
+
+func TestServiceQueryClientSideFallback(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		serviceQueryRequest,
+		`{ {{ template "first_page_variables" }}, "framework": null, "language": null, "lifecycle": null, "owner": null, "product": null, "sortBy": null, "tag": null, "tier": null }`,
+		`{ "data": { "account": { "services": { "nodes": `+serviceQueryTieredNodes+`, {{ template "no_pagination_response" }}, "totalCount": 3 }}}}`,
+	)
+	client := BestTestClient(t, "service/query_fallback", testRequest)
+	// Act
+	result, err := client.ServiceQuery().WithTier("tier_1", "tier_2").List()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, len(result))
+	autopilot.Equals(t, "Foo", result[0].Name)
+	autopilot.Equals(t, "Baz", result[1].Name)
+}
+
+func TestServiceQueryRepeatedCriteria(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		serviceQueryRequest,
+		`{ {{ template "first_page_variables" }}, "framework": null, "language": null, "lifecycle": null, "owner": null, "product": null, "sortBy": null, "tag": null, "tier": null }`,
+		`{ "data": { "account": { "services": { "nodes": `+serviceQueryTieredNodes+`, {{ template "no_pagination_response" }}, "totalCount": 3 }}}}`,
+	)
+	client := BestTestClient(t, "service/query_repeated", testRequest)
+	// Act
+	result, err := client.ServiceQuery().WithTier("tier_1").WithTier("tier_3").List()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, len(result))
+	autopilot.Equals(t, "Foo", result[0].Name)
+	autopilot.Equals(t, "Bar", result[1].Name)
+}
@@ -91,3 +91,9 @@
     "serviceCount":33,
     "totalChecks":33
 {{ end }}
+
+{{- define "scorecard_category_list_query" }}query ScorecardCategoryList($after:String!$first:Int!$scorecard:IdentifierInput!){account{scorecard(input: $scorecard){categories(after: $after, first: $first){nodes{id,name},{{ template "pagination_request" }},totalCount}}}}{{ end }}
+
+{{- define "scorecard_check_list_query" }}query ScorecardCheckList($after:String!$first:Int!$scorecard:IdentifierInput!){account{scorecard(input: $scorecard){checks(after: $after, first: $first){nodes{category{id,name},description,enabled,enableOn,filter{connective,htmlUrl,id,name,predicates{key,keyData,type,value,caseSensitive}},id,level{alias,description,id,index,name},name,notes: rawNotes,owner{... on Team{alias,id}},type,... on AlertSourceUsageCheck{alertSourceNamePredicate{type,value},alertSourceType},... on CustomEventCheck{integration{id,name,type},passPending,resultMessage,serviceSelector,successCondition},... on HasRecentDeployCheck{days},... on ManualCheck{updateFrequency{startingDate,frequencyTimeScale,frequencyValue},updateRequiresComment},... on RepositoryFileCheck{directorySearch,filePaths,fileContentsPredicate{type,value},useAbsoluteRoot},... on RepositoryGrepCheck{directorySearch,filePaths,fileContentsPredicate{type,value}},... on RepositorySearchCheck{fileExtensions,fileContentsPredicate{type,value}},... on ServiceOwnershipCheck{requireContactMethod,contactMethod,tagKey,tagPredicate{type,value}},... on ServicePropertyCheck{serviceProperty,propertyValuePredicate{type,value}},... on TagDefinedCheck{tagKey,tagPredicate{type,value}},... on ToolUsageCheck{toolCategory,toolNamePredicate{type,value},toolUrlPredicate{type,value},environmentPredicate{type,value}},... on HasDocumentationCheck{documentType,documentSubtype}},{{ template "pagination_request" }},totalCount}}}}{{ end }}
+
+{{- define "scorecard_service_score_list_query" }}query ScorecardServiceScoreList($after:String!$first:Int!$scorecard:IdentifierInput!$sortBy:ScorecardSortEnum!){account{scorecard(input: $scorecard){serviceScores(sortBy: $sortBy, after: $after, first: $first){nodes{service{id,aliases},passingChecks,totalChecks},{{ template "pagination_request" }},totalCount}}}}{{ end }}
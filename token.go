@@ -0,0 +1,195 @@
+package opslevel
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenProvider returns the API token to authenticate a request with, it is called for every request
+type TokenProvider interface {
+	Token() (string, error)
+}
+
+// TokenInvalidator is implemented by providers that cache their token - the client calls Invalidate when the API
+// rejects a token so the next call to Token fetches a fresh one
+type TokenInvalidator interface {
+	Invalidate()
+}
+
+type staticTokenProvider string
+
+// StaticTokenProvider always returns 'token'
+func StaticTokenProvider(token string) TokenProvider {
+	return staticTokenProvider(token)
+}
+
+func (p staticTokenProvider) Token() (string, error) {
+	return string(p), nil
+}
+
+type envTokenProvider string
+
+// EnvTokenProvider returns the value of the environment variable 'name' at the time of each request
+func EnvTokenProvider(name string) TokenProvider {
+	return envTokenProvider(name)
+}
+
+func (p envTokenProvider) Token() (string, error) {
+	token, ok := os.LookupEnv(string(p))
+	if !ok {
+		return "", fmt.Errorf("environment variable '%s' is not set", string(p))
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// FileTokenProvider returns the content of a file, re-reading it when its size or modification time changes
+type FileTokenProvider struct {
+	Path string
+
+	mutex   sync.Mutex
+	token   string
+	size    int64
+	modTime time.Time
+}
+
+func NewFileTokenProvider(path string) *FileTokenProvider {
+	return &FileTokenProvider{Path: path}
+}
+
+func (p *FileTokenProvider) Token() (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return "", err
+	}
+	if p.token != "" && info.Size() == p.size && info.ModTime().Equal(p.modTime) {
+		return p.token, nil
+	}
+	content, err := os.ReadFile(p.Path)
+	if err != nil {
+		return "", err
+	}
+	p.token = strings.TrimSpace(string(content))
+	p.size = info.Size()
+	p.modTime = info.ModTime()
+	return p.token, nil
+}
+
+func (p *FileTokenProvider) Invalidate() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.token = ""
+}
+
+// CommandTokenProvider returns the trimmed output of a command, e.g. a secret manager's CLI - wrap it
+// in a CachedTokenProvider to avoid running it for every request
+type CommandTokenProvider struct {
+	Name string
+	Args []string
+}
+
+func NewCommandTokenProvider(name string, args ...string) *CommandTokenProvider {
+	return &CommandTokenProvider{Name: name, Args: args}
+}
+
+func (p *CommandTokenProvider) Token() (string, error) {
+	output, err := exec.Command(p.Name, p.Args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("token command '%s' failed: %s", p.Name, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("token command '%s' failed: %w", p.Name, err)
+	}
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("token command '%s' returned an empty token", p.Name)
+	}
+	return token, nil
+}
+
+// CachedTokenProvider returns the token of the wrapped provider, fetching it again once TTL has passed or
+// after Invalidate - a TTL of 0 caches the token until it is invalidated
+type CachedTokenProvider struct {
+	Provider TokenProvider
+	TTL      time.Duration
+
+	mutex   sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func NewCachedTokenProvider(provider TokenProvider, ttl time.Duration) *CachedTokenProvider {
+	return &CachedTokenProvider{Provider: provider, TTL: ttl}
+}
+
+func (p *CachedTokenProvider) Token() (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.token != "" && (p.TTL == 0 || time.Now().Before(p.expires)) {
+		return p.token, nil
+	}
+	token, err := p.Provider.Token()
+	if err != nil {
+		return "", err
+	}
+	p.token = token
+	p.expires = time.Now().Add(p.TTL)
+	return token, nil
+}
+
+func (p *CachedTokenProvider) Invalidate() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.token = ""
+	if invalidator, ok := p.Provider.(TokenInvalidator); ok {
+		invalidator.Invalidate()
+	}
+}
+
+// tokenTransport sets the Authorization header of each request and, when the API responds with 401, retries
+// once with a refreshed token
+type tokenTransport struct {
+	base     http.RoundTripper
+	provider TokenProvider
+}
+
+func (t *tokenTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	token, err := t.provider.Token()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get api token: %w", err)
+	}
+	response, err := t.base.RoundTrip(t.authorize(request, token))
+	if err != nil || response.StatusCode != http.StatusUnauthorized || request.GetBody == nil {
+		return response, err
+	}
+
+	if invalidator, ok := t.provider.(TokenInvalidator); ok {
+		invalidator.Invalidate()
+	}
+	refreshed, err := t.provider.Token()
+	if err != nil || refreshed == token {
+		return response, nil
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return response, nil
+	}
+	response.Body.Close()
+	retry := t.authorize(request, refreshed)
+	retry.Body = body
+	return t.base.RoundTrip(retry)
+}
+
+func (t *tokenTransport) authorize(request *http.Request, token string) *http.Request {
+	output := request.Clone(request.Context())
+	output.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return output
+}
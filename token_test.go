@@ -0,0 +1,147 @@
+package opslevel_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+// rotatingTokenProvider returns the next token of the list each time it is invalidated
+type rotatingTokenProvider struct {
+	tokens []string
+	calls  int
+}
+
+func (p *rotatingTokenProvider) Token() (string, error) {
+	p.calls++
+	return p.tokens[0], nil
+}
+
+func (p *rotatingTokenProvider) Invalidate() {
+	if len(p.tokens) > 1 {
+		p.tokens = p.tokens[1:]
+	}
+}
+
+func TestEnvTokenProvider(t *testing.T) {
+	// Arrange
+	t.Setenv("OPSLEVEL_TEST_TOKEN", " secret\n")
+	provider := ol.EnvTokenProvider("OPSLEVEL_TEST_TOKEN")
+	// Act
+	token, err := provider.Token()
+	_, missingErr := ol.EnvTokenProvider("OPSLEVEL_TEST_TOKEN_MISSING").Token()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "secret", token)
+	autopilot.Equals(t, "environment variable 'OPSLEVEL_TEST_TOKEN_MISSING' is not set", missingErr.Error())
+}
+
+func TestFileTokenProviderRereadsOnChange(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "token")
+	autopilot.Ok(t, os.WriteFile(path, []byte("first\n"), 0o600))
+	provider := ol.NewFileTokenProvider(path)
+	// Act
+	first, err := provider.Token()
+	autopilot.Ok(t, err)
+	autopilot.Ok(t, os.WriteFile(path, []byte("rotated\n"), 0o600))
+	second, err := provider.Token()
+	autopilot.Ok(t, err)
+	// Assert
+	autopilot.Equals(t, "first", first)
+	autopilot.Equals(t, "rotated", second)
+}
+
+func TestCommandTokenProvider(t *testing.T) {
+	// Arrange
+	provider := ol.NewCommandTokenProvider("echo", "from-command")
+	// Act
+	token, err := provider.Token()
+	_, emptyErr := ol.NewCommandTokenProvider("true").Token()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "from-command", token)
+	autopilot.Equals(t, "token command 'true' returned an empty token", emptyErr.Error())
+}
+
+func TestCachedTokenProvider(t *testing.T) {
+	// Arrange
+	source := &rotatingTokenProvider{tokens: []string{"one", "two"}}
+	provider := ol.NewCachedTokenProvider(source, 0)
+	// Act
+	first, _ := provider.Token()
+	cached, _ := provider.Token()
+	provider.Invalidate()
+	refreshed, _ := provider.Token()
+	// Assert
+	autopilot.Equals(t, "one", first)
+	autopilot.Equals(t, "one", cached)
+	autopilot.Equals(t, "two", refreshed)
+	autopilot.Equals(t, 2, source.calls)
+}
+
+func TestClientRetriesUnauthorizedWithRefreshedToken(t *testing.T) {
+	// Arrange
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"account": {"id": "1"}}}`))
+	}))
+	defer server.Close()
+	provider := &rotatingTokenProvider{tokens: []string{"old", "new"}}
+	client := ol.NewGQLClient(ol.SetURL(server.URL), ol.SetMaxRetries(0), ol.SetTokenProvider(provider))
+	// Act
+	err := client.Validate()
+	again := client.Validate()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Ok(t, again)
+	autopilot.Equals(t, []string{"Bearer old", "Bearer new", "Bearer new"}, authorizations)
+}
+
+func TestClientUnauthorizedWithoutNewToken(t *testing.T) {
+	// Arrange
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	client := ol.NewGQLClient(ol.SetURL(server.URL), ol.SetMaxRetries(0), ol.SetAPIToken("static"))
+	// Act
+	err := client.Validate()
+	// Assert
+	autopilot.Assert(t, err != nil, "expected the unauthorized response to fail")
+	autopilot.Equals(t, 1, requests)
+}
+
+func TestClientDefaultTokenFromEnvironment(t *testing.T) {
+	// Arrange
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"data": {"account": {"id": "1"}}}`))
+	}))
+	defer server.Close()
+	t.Setenv("OPSLEVEL_API_TOKEN", "from-env")
+	withToken := ol.NewGQLClient(ol.SetURL(server.URL), ol.SetMaxRetries(0))
+	os.Unsetenv("OPSLEVEL_API_TOKEN")
+	withoutToken := ol.NewGQLClient(ol.SetURL(server.URL), ol.SetMaxRetries(0))
+	// Act
+	err := withToken.Validate()
+	missingErr := withoutToken.Validate()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Ok(t, missingErr)
+	autopilot.Equals(t, 2, len(authorizations))
+	autopilot.Equals(t, "Bearer from-env", authorizations[0])
+}
@@ -0,0 +1,89 @@
+package opslevel
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// ConnectionPool limits the connections kept to the API - zero values keep the transport's defaults
+type ConnectionPool struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+}
+
+func (p ConnectionPool) isSet() bool {
+	return p != ConnectionPool{}
+}
+
+func (p ConnectionPool) apply(transport *http.Transport) {
+	if p.MaxIdleConns > 0 {
+		transport.MaxIdleConns = p.MaxIdleConns
+	}
+	if p.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = p.MaxIdleConnsPerHost
+	}
+	if p.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = p.MaxConnsPerHost
+	}
+	if p.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = p.IdleConnTimeout
+	}
+}
+
+// NewTLSConfig builds a TLS configuration trusting the CA bundle at 'caFile', in addition to the system roots,
+// and presenting the client certificate in 'certFile' and 'keyFile' - any of the files can be left empty
+func NewTLSConfig(caFile string, certFile string, keyFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		bundle, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in CA bundle '%s'", caFile)
+		}
+		config.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+// roundTripper returns the transport configured by the options or nil to use the HTTP client's default
+func (s *ClientSettings) roundTripper() http.RoundTripper {
+	if s.proxy == nil && s.tlsConfig == nil && !s.connections.isSet() {
+		return s.transport
+	}
+	var transport *http.Transport
+	switch base := s.transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = base.Clone()
+	default:
+		return s.transport
+	}
+	if s.proxy != nil {
+		transport.Proxy = http.ProxyURL(s.proxy)
+	}
+	if s.tlsConfig != nil {
+		transport.TLSClientConfig = s.tlsConfig
+	}
+	s.connections.apply(transport)
+	return transport
+}
@@ -0,0 +1,101 @@
+package opslevel_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ol "github.com/opslevel/opslevel-go/v2023"
+	"github.com/rocktavious/autopilot/v2023"
+)
+
+type countingTransport struct {
+	requests []string
+}
+
+func (t *countingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, request.URL.Path)
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func accountHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": {"account": {"id": "1"}}}`))
+	})
+}
+
+func TestSetTransportAppliesToGQLAndRest(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(accountHandler())
+	defer server.Close()
+	transport := &countingTransport{}
+	options := []ol.Option{ol.SetURL(server.URL), ol.SetAPIToken("x"), ol.SetMaxRetries(0), ol.SetTransport(transport)}
+	// Act
+	err := ol.NewGQLClient(options...).Validate()
+	_, restErr := ol.NewRestClient(options...).R().Get("/api/ping")
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Ok(t, restErr)
+	autopilot.Equals(t, []string{"/graphql", "/api/ping"}, transport.requests)
+}
+
+func TestSetHTTPClient(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(accountHandler())
+	defer server.Close()
+	transport := &countingTransport{}
+	httpClient := &http.Client{Transport: transport}
+	// Act
+	err := ol.NewGQLClient(ol.SetURL(server.URL), ol.SetAPIToken("x"), ol.SetMaxRetries(0), ol.SetHTTPClient(httpClient)).Validate()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []string{"/graphql"}, transport.requests)
+}
+
+func TestSetProxy(t *testing.T) {
+	// Arrange
+	var hosts []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.URL.Host)
+		accountHandler().ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	autopilot.Ok(t, err)
+	// Act
+	err = ol.NewGQLClient(ol.SetURL("http://api.opslevel.invalid"), ol.SetAPIToken("x"), ol.SetMaxRetries(0), ol.SetProxy(proxyURL)).Validate()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, []string{"api.opslevel.invalid"}, hosts)
+}
+
+func TestSetTLSConfigWithCABundle(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(accountHandler())
+	defer server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	autopilot.Ok(t, os.WriteFile(caFile, bundle, 0o600))
+	config, err := ol.NewTLSConfig(caFile, "", "")
+	autopilot.Ok(t, err)
+	// Act
+	trusted := ol.NewGQLClient(ol.SetURL(server.URL), ol.SetAPIToken("x"), ol.SetMaxRetries(0), ol.SetTLSConfig(config)).Validate()
+	untrusted := ol.NewGQLClient(ol.SetURL(server.URL), ol.SetAPIToken("x"), ol.SetMaxRetries(0)).Validate()
+	// Assert
+	autopilot.Ok(t, trusted)
+	autopilot.Assert(t, untrusted != nil, "expected the server certificate to be rejected without the CA bundle")
+}
+
+func TestNewTLSConfigInvalidBundle(t *testing.T) {
+	// Arrange
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	autopilot.Ok(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+	// Act
+	_, err := ol.NewTLSConfig(caFile, "", "")
+	// Assert
+	autopilot.Equals(t, "no certificates found in CA bundle '"+caFile+"'", err.Error())
+}
@@ -2,9 +2,13 @@ package opslevel
 
 import (
 	"fmt"
+	"reflect"
 	"slices"
+	"strings"
 )
 
+const userTeamsChunkSize = 20
+
 type MemberInput struct {
 	Email string `json:"email"`
 }
@@ -34,6 +38,20 @@ type UserIdentifierInput struct {
 	Email string `graphql:"email" json:"email,omitempty"`
 }
 
+// UsersFilterInput filters ListUsersWithFilter on the server, all filters must match.
+// A nil Arg is sent as null to compare against a field that is not set, e.g. deactivated_at.
+type UsersFilterInput struct {
+	Key  UsersFilterEnum `json:"key"`
+	Arg  *string         `json:"arg"`
+	Type BasicTypeEnum   `json:"type,omitempty"`
+}
+
+// UserRoleAudit lists the users an account owner should review
+type UserRoleAudit struct {
+	Admins      []User
+	WithoutTeam []User // Users with no team memberships
+}
+
 type UserInput struct {
 	Name             string   `json:"name,omitempty"`
 	Role             UserRole `json:"role,omitempty"`
@@ -61,6 +79,32 @@ func NewUserIdentifier(value string) UserIdentifierInput {
 	}
 }
 
+func NewUsersFilter(key UsersFilterEnum, arg string) UsersFilterInput {
+	return UsersFilterInput{Key: key, Arg: NewString(arg), Type: BasicTypeEnumEquals}
+}
+
+// UsersFilterActive matches users whose deactivated_at is null
+func UsersFilterActive() UsersFilterInput {
+	return UsersFilterInput{Key: UsersFilterEnumDeactivatedAt, Arg: nil, Type: BasicTypeEnumEquals}
+}
+
+// UsersFilterDeactivated matches users whose deactivated_at is not null
+func UsersFilterDeactivated() UsersFilterInput {
+	return UsersFilterInput{Key: UsersFilterEnumDeactivatedAt, Arg: nil, Type: BasicTypeEnumDoesNotEqual}
+}
+
+// Search returns the users whose name or email contains 'term' ignoring case
+func (c *UserConnection) Search(term string) []User {
+	term = strings.ToLower(term)
+	var output []User
+	for _, user := range c.Nodes {
+		if strings.Contains(strings.ToLower(user.Name), term) || strings.Contains(strings.ToLower(user.Email), term) {
+			output = append(output, user)
+		}
+	}
+	return output
+}
+
 func (u *UserId) GetTags(client *Client, variables *PayloadVariables) (*TagConnection, error) {
 	var q struct {
 		Account struct {
@@ -192,6 +236,91 @@ func (client *Client) ListUsers(variables *PayloadVariables) (UserConnection, er
 	return q.Account.Users, nil
 }
 
+// ListUsersWithFilter lists the users matching every filter, e.g. NewUsersFilter(UsersFilterEnumRole, string(UserRoleAdmin))
+func (client *Client) ListUsersWithFilter(filter []UsersFilterInput, variables *PayloadVariables) (UserConnection, error) {
+	var q struct {
+		Account struct {
+			Users UserConnection `graphql:"users(after: $after, filter: $filter, first: $first)"`
+		}
+	}
+	if variables == nil {
+		variables = client.InitialPageVariablesPointer()
+	}
+	(*variables)["filter"] = filter
+
+	if err := client.Query(&q, *variables, WithName("UserListWithFilter")); err != nil {
+		return UserConnection{}, err
+	}
+
+	for q.Account.Users.PageInfo.HasNextPage {
+		(*variables)["after"] = q.Account.Users.PageInfo.End
+		resp, err := client.ListUsersWithFilter(filter, variables)
+		if err != nil {
+			return UserConnection{}, err
+		}
+		q.Account.Users.Nodes = append(q.Account.Users.Nodes, resp.Nodes...)
+		q.Account.Users.PageInfo = resp.PageInfo
+		q.Account.Users.TotalCount += resp.TotalCount
+	}
+	return q.Account.Users, nil
+}
+
+// AuditUserRoles reviews the active users returning the admins and the users that belong to no team
+func (client *Client) AuditUserRoles() (*UserRoleAudit, error) {
+	users, err := client.ListUsersWithFilter([]UsersFilterInput{UsersFilterActive()}, nil)
+	if err != nil {
+		return nil, err
+	}
+	output := &UserRoleAudit{}
+	for _, user := range users.Nodes {
+		if user.Role == UserRoleAdmin {
+			output.Admins = append(output.Admins, user)
+		}
+	}
+	withoutTeam, err := client.usersWithoutTeam(users.Nodes)
+	if err != nil {
+		return nil, err
+	}
+	output.WithoutTeam = withoutTeam
+	return output, nil
+}
+
+// usersWithoutTeam looks up the first page of teams of the users in batches instead of one request per user
+func (client *Client) usersWithoutTeam(users []User) ([]User, error) {
+	type node struct {
+		Teams TeamIdConnection `graphql:"teams(first: $first)"`
+	}
+	var output []User
+	for start := 0; start < len(users); start += userTeamsChunkSize {
+		chunk := users[start:min(start+userTeamsChunkSize, len(users))]
+		fields := make([]reflect.StructField, len(chunk))
+		v := PayloadVariables{"first": client.pageSize}
+		for i, user := range chunk {
+			name := fmt.Sprintf("u%d", i)
+			v[name] = user.Id
+			fields[i] = reflect.StructField{
+				Name: fmt.Sprintf("U%d", i),
+				Type: reflect.TypeOf(node{}),
+				Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"%s: user(id: $%s)"`, name, name)),
+			}
+		}
+		q := reflect.New(reflect.StructOf([]reflect.StructField{{
+			Name: "Account",
+			Type: reflect.StructOf(fields),
+		}}))
+		if err := client.Query(q.Interface(), v, WithName("UsersTeamsList")); err != nil {
+			return nil, err
+		}
+		account := q.Elem().Field(0)
+		for i, user := range chunk {
+			if len(account.Field(i).Interface().(node).Teams.Nodes) == 0 {
+				output = append(output, user)
+			}
+		}
+	}
+	return output, nil
+}
+
 //#endregion
 
 //#region Update
@@ -211,6 +340,34 @@ func (client *Client) UpdateUser(user string, input UserInput) (*User, error) {
 	return &m.Payload.User, HandleErrors(err, m.Payload.Errors)
 }
 
+func (client *Client) DeactivateUser(user string) (*User, error) {
+	var m struct {
+		Payload struct {
+			User   User
+			Errors []OpsLevelErrors
+		} `graphql:"userDeactivate(user: $user)"`
+	}
+	v := PayloadVariables{
+		"user": NewUserIdentifier(user),
+	}
+	err := client.Mutate(&m, v, WithName("UserDeactivate"))
+	return &m.Payload.User, HandleErrors(err, m.Payload.Errors)
+}
+
+func (client *Client) ReactivateUser(user string) (*User, error) {
+	var m struct {
+		Payload struct {
+			User   User
+			Errors []OpsLevelErrors
+		} `graphql:"userReactivate(user: $user)"`
+	}
+	v := PayloadVariables{
+		"user": NewUserIdentifier(user),
+	}
+	err := client.Mutate(&m, v, WithName("UserReactivate"))
+	return &m.Payload.User, HandleErrors(err, m.Payload.Errors)
+}
+
 //#endregion
 
 //#region Delete
@@ -234,3 +234,110 @@ func TestGetUserTags(t *testing.T) {
 	autopilot.Equals(t, "captain", result[3].Key)
 	autopilot.Equals(t, "tuna", result[3].Value)
 }
+
+func TestListUsersWithFilter(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query UserListWithFilter($after:String!$filter:[UsersFilterInput!]!$first:Int!){account{users(after: $after, filter: $filter, first: $first){nodes{id,email,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}"`,
+		`{ {{ template "first_page_variables" }}, "filter": [ { "key": "role", "arg": "admin", "type": "equals" }, { "key": "deactivated_at", "arg": null, "type": "equals" } ] }`,
+		`{ "data": { "account": { "users": { "nodes": [ {{ template "user_2" }} ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}`,
+	)
+	client := BestTestClient(t, "user/list_with_filter", testRequest)
+	// Act
+	response, err := client.ListUsersWithFilter([]ol.UsersFilterInput{
+		ol.NewUsersFilter(ol.UsersFilterEnumRole, string(ol.UserRoleAdmin)),
+		ol.UsersFilterActive(),
+	}, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, response.TotalCount)
+	autopilot.Equals(t, "Edgar Ochoa", response.Nodes[0].Name)
+}
+
+func TestListUsersWithFilterDeactivated(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"query UserListWithFilter($after:String!$filter:[UsersFilterInput!]!$first:Int!){account{users(after: $after, filter: $filter, first: $first){nodes{id,email,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}"`,
+		`{ {{ template "first_page_variables" }}, "filter": [ { "key": "deactivated_at", "arg": null, "type": "does_not_equal" } ] }`,
+		`{ "data": { "account": { "users": { "nodes": [ {{ template "user_1" }} ], {{ template "no_pagination_response" }}, "totalCount": 1 }}}}`,
+	)
+	client := BestTestClient(t, "user/list_deactivated", testRequest)
+	// Act
+	response, err := client.ListUsersWithFilter([]ol.UsersFilterInput{ol.UsersFilterDeactivated()}, nil)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "Kyle Rockman", response.Nodes[0].Name)
+}
+
+func TestUserConnectionSearch(t *testing.T) {
+	// Arrange
+	users := ol.UserConnection{Nodes: []ol.User{
+		{UserId: ol.UserId{Id: id1, Email: "kyle@opslevel.com"}, Name: "Kyle Rockman"},
+		{UserId: ol.UserId{Id: id2, Email: "edgar@opslevel.com"}, Name: "Edgar Ochoa"},
+	}}
+	// Act
+	byName := users.Search("rock")
+	byEmail := users.Search("EDGAR@")
+	// Assert
+	autopilot.Equals(t, 1, len(byName))
+	autopilot.Equals(t, id1, byName[0].Id)
+	autopilot.Equals(t, 1, len(byEmail))
+	autopilot.Equals(t, id2, byEmail[0].Id)
+	autopilot.Equals(t, 0, len(users.Search("nobody")))
+}
+
+func TestDeactivateUser(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation UserDeactivate($user:UserIdentifierInput!){userDeactivate(user: $user){user{id,email,htmlUrl,name,role},errors{message,path}}}"`,
+		`{"user": {"email": "kyle@opslevel.com" }}`,
+		`{"data": {"userDeactivate": {"user": {{ template "user_1" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "user/deactivate", testRequest)
+	// Act
+	result, err := client.DeactivateUser("kyle@opslevel.com")
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, id1, result.Id)
+}
+
+func TestReactivateUser(t *testing.T) {
+	// Arrange
+	testRequest := NewTestRequest(
+		`"mutation UserReactivate($user:UserIdentifierInput!){userReactivate(user: $user){user{id,email,htmlUrl,name,role},errors{message,path}}}"`,
+		`{"user": { {{ template "id1" }} }}`,
+		`{"data": {"userReactivate": {"user": {{ template "user_1" }}, "errors": [] }}}`,
+	)
+	client := BestTestClient(t, "user/reactivate", testRequest)
+	// Act
+	result, err := client.ReactivateUser(string(id1))
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "Kyle Rockman", result.Name)
+}
+
+func TestAuditUserRoles(t *testing.T) {
+	// Arrange
+	testRequestOne := NewTestRequest(
+		`"query UserListWithFilter($after:String!$filter:[UsersFilterInput!]!$first:Int!){account{users(after: $after, filter: $filter, first: $first){nodes{id,email,htmlUrl,name,role},{{ template "pagination_request" }},totalCount}}}"`,
+		`{ {{ template "first_page_variables" }}, "filter": [ { "key": "deactivated_at", "arg": null, "type": "equals" } ] }`,
+		`{ "data": { "account": { "users": { "nodes": [ {{ template "user_1" }}, {{ template "user_2" }} ], {{ template "no_pagination_response" }}, "totalCount": 2 }}}}`,
+	)
+	testRequestTwo := NewTestRequest(
+		`"query UsersTeamsList($first:Int!$u0:ID!$u1:ID!){account{u0: user(id: $u0){teams(first: $first){nodes{alias,id},{{ template "pagination_request" }},totalCount}},u1: user(id: $u1){teams(first: $first){nodes{alias,id},{{ template "pagination_request" }},totalCount}}}}"`,
+		`{ "first": 100, "u0": "{{ template "id1_string" }}", "u1": "{{ template "id2_string" }}" }`,
+		`{ "data": { "account": {
+          "u0": { "teams": { "nodes": [], {{ template "no_pagination_response" }}, "totalCount": 0 }},
+          "u1": { "teams": { "nodes": [ { {{ template "teamId_1" }} } ], {{ template "no_pagination_response" }}, "totalCount": 1 }}
+        }}}`,
+	)
+	client := BestTestClient(t, "user/audit_roles", testRequestOne, testRequestTwo)
+	// Act
+	result, err := client.AuditUserRoles()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 1, len(result.Admins))
+	autopilot.Equals(t, "Edgar Ochoa", result.Admins[0].Name)
+	autopilot.Equals(t, 1, len(result.WithoutTeam))
+	autopilot.Equals(t, "Kyle Rockman", result.WithoutTeam[0].Name)
+}